@@ -9,10 +9,61 @@ import (
 type Repository struct {
 	Name string `json:"name"`
 	Path string `json:"path"`
+	// WorktreePath overrides the global worktree path template.
+	WorktreePath string `json:"worktree_path,omitempty"`
+	// UpdateStrategy is the strategy preselected when updating one of the
+	// repository's worktrees: "rebase" (the default), "autostash", "merge"
+	// or "ff-only".
+	UpdateStrategy string `json:"update_strategy,omitempty"`
+	// BaseRemote is the remote the default branch is fetched from, and
+	// PushRemote the one new branches are pushed to. Both are detected
+	// from the repository's git config when empty; see git.Remotes.
+	BaseRemote string `json:"base_remote,omitempty"`
+	PushRemote string `json:"push_remote,omitempty"`
 }
 
 type Config struct {
 	Repos []Repository `json:"repos"`
+	// WorktreePath is the template for new worktree directories; see
+	// ExpandWorktreePath. Empty means DefaultWorktreePath.
+	WorktreePath string `json:"worktree_path,omitempty"`
+	// OnConflict decides what happens when updating a worktree hits
+	// conflicts: "abort" (the default) rolls the rebase back, "resolve"
+	// leaves it in progress and opens the conflicts view.
+	OnConflict string `json:"on_conflict,omitempty"`
+	// AIAgents customises how commits made with AI agents are recognised;
+	// nil keeps the built-in rules.
+	AIAgents *AIAgents `json:"ai_agents,omitempty"`
+}
+
+// AIAgents lists what marks a commit as made with an AI agent. Each
+// non-empty list replaces the corresponding built-in one; see
+// git.AgentRules.
+type AIAgents struct {
+	// Names are the agent names looked for in trailer values.
+	Names []string `json:"names,omitempty"`
+	// Trailers are the trailer keys looked at, e.g. "Co-authored-by".
+	Trailers []string `json:"trailers,omitempty"`
+	// Emails maps patterns ("*" is a wildcard) on author, committer and
+	// trailer emails to the agent name shown for them.
+	Emails map[string]string `json:"emails,omitempty"`
+}
+
+// KeepConflicts reports whether conflicting rebases should be left in
+// progress for interactive resolution.
+func (c Config) KeepConflicts() bool {
+	return c.OnConflict == "resolve"
+}
+
+// UpdateStrategy returns the configured update strategy name for repo, or
+// "" when it has none.
+func (c Config) UpdateStrategy(repoPath string) string {
+	for _, r := range c.Repos {
+		if r.Path == repoPath {
+			return r.UpdateStrategy
+		}
+	}
+	return ""
 }
 
 func configPath() (string, error) {
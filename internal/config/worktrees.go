@@ -0,0 +1,84 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// DefaultWorktreePath places worktrees next to the repository, or next to
+// the git directory of a bare one.
+const DefaultWorktreePath = "{repo_parent}/{name}"
+
+var (
+	templateVar  = regexp.MustCompile(`\{([a-z_]+)\}`)
+	unsafeInSlug = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+)
+
+// WorktreeTemplate returns the path template that applies to repo: its own
+// setting, then the global one, then DefaultWorktreePath.
+func (c Config) WorktreeTemplate(repoPath string) string {
+	for _, r := range c.Repos {
+		if r.Path == repoPath && r.WorktreePath != "" {
+			return r.WorktreePath
+		}
+	}
+	if c.WorktreePath != "" {
+		return c.WorktreePath
+	}
+	return DefaultWorktreePath
+}
+
+// ExpandWorktreePath fills in a worktree path template. Supported variables
+// are {repo}, {repo_parent}, {name}, {branch} and {branch_slug} (the branch
+// with slashes and other unsafe characters replaced by "-"). A leading "~"
+// expands to the home directory; relative results are taken relative to
+// {repo_parent}.
+//
+// bareDir is the git directory of a bare repository and empty otherwise.
+// Worktrees of a bare repository are laid out around that directory
+// instead: {repo_parent} is the directory holding it and {repo} its name
+// without ".git", so "app.git" and "app/.bare" both yield worktrees beside
+// their git directory.
+func ExpandWorktreePath(template, repoPath, bareDir, name, branch string) (string, error) {
+	repo, parent := filepath.Base(repoPath), filepath.Dir(repoPath)
+	if bareDir != "" {
+		parent = filepath.Dir(bareDir)
+		repo = strings.TrimSuffix(filepath.Base(bareDir), ".git")
+		if repo == "" || strings.HasPrefix(repo, ".") {
+			repo = filepath.Base(parent)
+		}
+	}
+	vars := map[string]string{
+		"repo":        repo,
+		"repo_parent": parent,
+		"name":        name,
+		"branch":      branch,
+		"branch_slug": strings.Trim(unsafeInSlug.ReplaceAllString(branch, "-"), "-"),
+	}
+	var unknown string
+	path := templateVar.ReplaceAllStringFunc(template, func(m string) string {
+		key := m[1 : len(m)-1]
+		v, ok := vars[key]
+		if !ok && unknown == "" {
+			unknown = m
+		}
+		return v
+	})
+	if unknown != "" {
+		return "", fmt.Errorf("unknown variable %s in worktree path %q", unknown, template)
+	}
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, path[1:])
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(parent, path)
+	}
+	return filepath.Clean(path), nil
+}
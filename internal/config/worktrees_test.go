@@ -0,0 +1,79 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExpandWorktreePath(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{DefaultWorktreePath, "/src/feat"},
+		{"{repo_parent}/{repo}.worktrees/{name}", "/src/app.worktrees/feat"},
+		{"~/worktrees/{repo}/{branch_slug}", filepath.Join(home, "worktrees/app/team-x-fix-1")},
+		{"{repo}-wt/{branch}", "/src/app-wt/team/x/fix#1"},
+	}
+	for _, tt := range tests {
+		got, err := ExpandWorktreePath(tt.template, "/src/app", "", "feat", "team/x/fix#1")
+		if err != nil {
+			t.Fatalf("%s: %v", tt.template, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.template, got, tt.want)
+		}
+	}
+}
+
+func TestExpandWorktreePathBare(t *testing.T) {
+	tests := []struct {
+		repoPath, bareDir string
+		template          string
+		want              string
+	}{
+		{"/src/app.git", "/src/app.git", DefaultWorktreePath, "/src/feat"},
+		{"/src/app.git", "/src/app.git", "{repo_parent}/{repo}-{name}", "/src/app-feat"},
+		{"/src/app", "/src/app/.bare", DefaultWorktreePath, "/src/app/feat"},
+		{"/src/app", "/src/app/.bare", "{repo}/{name}", "/src/app/app/feat"},
+	}
+	for _, tt := range tests {
+		got, err := ExpandWorktreePath(tt.template, tt.repoPath, tt.bareDir, "feat", "feat")
+		if err != nil {
+			t.Fatalf("%s: %v", tt.template, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s in %s = %q, want %q", tt.template, tt.bareDir, got, tt.want)
+		}
+	}
+}
+
+func TestExpandWorktreePathUnknownVariable(t *testing.T) {
+	if _, err := ExpandWorktreePath("{repo}/{nope}", "/src/app", "", "feat", "feat"); err == nil {
+		t.Fatal("expected an error for an unknown variable")
+	}
+}
+
+func TestWorktreeTemplatePrecedence(t *testing.T) {
+	cfg := Config{
+		Repos: []Repository{
+			{Name: "app", Path: "/src/app", WorktreePath: "{repo_parent}/{repo}.worktrees/{name}"},
+			{Name: "lib", Path: "/src/lib"},
+		},
+	}
+	if got := cfg.WorktreeTemplate("/src/lib"); got != DefaultWorktreePath {
+		t.Errorf("lib template = %q, want default", got)
+	}
+	cfg.WorktreePath = "~/wt/{repo}/{name}"
+	if got := cfg.WorktreeTemplate("/src/lib"); got != "~/wt/{repo}/{name}" {
+		t.Errorf("lib template = %q, want global", got)
+	}
+	if got := cfg.WorktreeTemplate("/src/app"); got != "{repo_parent}/{repo}.worktrees/{name}" {
+		t.Errorf("app template = %q, want per-repo", got)
+	}
+}
@@ -0,0 +1,176 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// AgentRules decide which commits were made with an AI agent. A commit
+// matches when one of its trailers keyed by one of Trailers names one of
+// Names, or when the email of such a trailer, of its author or of its
+// committer matches a key of Emails. Emails maps patterns such as
+// "*@bots.example.com", where * matches any run of characters, to the
+// agent name reported for them. Matching ignores case throughout.
+type AgentRules struct {
+	Names    []string
+	Trailers []string
+	Emails   map[string]string
+}
+
+// DefaultAgentRules recognise the agents that sign their commits with a
+// trailer out of the box.
+var DefaultAgentRules = AgentRules{
+	Names:    []string{"Copilot", "Goose", "Claude", "Cursor", "Amp"},
+	Trailers: []string{"Co-authored-by", "Generated-by", "Assisted-by"},
+}
+
+type emailRule struct {
+	pattern *regexp.Regexp
+	agent   string
+}
+
+// agentDetector is the compiled form of AgentRules.
+type agentDetector struct {
+	// trailer matches a trailer line, capturing its value. It is not
+	// anchored: some commits have the trailer squashed into the subject.
+	trailer *regexp.Regexp
+	// name matches an agent name in a trailer value.
+	name   *regexp.Regexp
+	names  map[string]string
+	emails []emailRule
+}
+
+type agentSettings struct {
+	mu       sync.RWMutex
+	detector *agentDetector
+}
+
+var defaultAgentDetector = mustCompileAgentRules(DefaultAgentRules)
+
+func mustCompileAgentRules(r AgentRules) *agentDetector {
+	d, err := compileAgentRules(r)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func compileAgentRules(r AgentRules) (*agentDetector, error) {
+	d := &agentDetector{names: make(map[string]string)}
+	var keys []string
+	for _, t := range r.Trailers {
+		if t = strings.TrimSuffix(strings.TrimSpace(t), ":"); t != "" {
+			keys = append(keys, regexp.QuoteMeta(t))
+		}
+	}
+	if len(keys) > 0 {
+		d.trailer = regexp.MustCompile(`(?i)\b(?:` + strings.Join(keys, "|") + `):[ \t]*([^\n]*)`)
+	}
+	var names []string
+	for _, n := range r.Names {
+		if n = strings.TrimSpace(n); n != "" {
+			d.names[strings.ToLower(n)] = n
+			names = append(names, regexp.QuoteMeta(n))
+		}
+	}
+	if len(names) > 0 {
+		// Longest first, so "Claude Code" wins over "Claude". The name
+		// ends at a non-word character rather than \b, which never
+		// matches after names such as "renovate[bot]".
+		sort.Slice(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+		d.name = regexp.MustCompile(`(?i)^(` + strings.Join(names, "|") + `)(?:$|[^\w])`)
+	}
+	for pattern, agent := range r.Emails {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" || agent == "" {
+			return nil, fmt.Errorf("email pattern %q needs a pattern and an agent name", pattern)
+		}
+		expr := strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*")
+		d.emails = append(d.emails, emailRule{pattern: regexp.MustCompile(`(?i)^` + expr + `$`), agent: agent})
+	}
+	// Map order is random; sort so overlapping patterns resolve the same
+	// way every time.
+	sort.Slice(d.emails, func(i, j int) bool {
+		return d.emails[i].pattern.String() < d.emails[j].pattern.String()
+	})
+	return d, nil
+}
+
+// SetAgentRules replaces the rules used to recognise commits made with an
+// AI agent. The rules in effect are left alone when r is invalid.
+func (c *Client) SetAgentRules(r AgentRules) error {
+	d, err := compileAgentRules(r)
+	if err != nil {
+		return err
+	}
+	c.agents.mu.Lock()
+	c.agents.detector = d
+	c.agents.mu.Unlock()
+	// Cached stats were counted with the old rules.
+	c.ai.reset()
+	return nil
+}
+
+func (c *Client) agentDetector() *agentDetector {
+	c.agents.mu.RLock()
+	defer c.agents.mu.RUnlock()
+	if c.agents.detector == nil {
+		return defaultAgentDetector
+	}
+	return c.agents.detector
+}
+
+// emailAgent returns the agent whose pattern matches email, or "".
+func (d *agentDetector) emailAgent(email string) string {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return ""
+	}
+	for _, r := range d.emails {
+		if r.pattern.MatchString(email) {
+			return r.agent
+		}
+	}
+	return ""
+}
+
+// valueAgent returns the agent named by a trailer value such as
+// "Claude <noreply@anthropic.com>", or "".
+func (d *agentDetector) valueAgent(value string) string {
+	value = strings.TrimSpace(value)
+	if d.name != nil {
+		if m := d.name.FindStringSubmatch(value); m != nil {
+			return d.names[strings.ToLower(m[1])]
+		}
+	}
+	if _, rest, ok := strings.Cut(value, "<"); ok {
+		email, _, _ := strings.Cut(rest, ">")
+		return d.emailAgent(email)
+	}
+	return ""
+}
+
+// detect returns the agents a commit was made with, in the order they are
+// first mentioned: trailers of the message first, then the author and
+// committer emails.
+func (d *agentDetector) detect(message, authorEmail, committerEmail string) []string {
+	var agents []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			agents = append(agents, name)
+		}
+	}
+	if d.trailer != nil {
+		for _, m := range d.trailer.FindAllStringSubmatch(message, -1) {
+			add(d.valueAgent(m[1]))
+		}
+	}
+	add(d.emailAgent(authorEmail))
+	add(d.emailAgent(committerEmail))
+	return agents
+}
@@ -0,0 +1,106 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/marcellolins/mossy/internal/git/gittest"
+)
+
+func TestDetectAgentsDefaults(t *testing.T) {
+	tests := []struct {
+		message string
+		want    []string
+	}{
+		{"Fix\n\nCo-authored-by: Claude <noreply@anthropic.com>", []string{"Claude"}},
+		{"Fix\n\nco-authored-by: copilot <x@github.com>", []string{"Copilot"}},
+		{"Fix Co-authored-by: Amp <amp@ampcode.com>", []string{"Amp"}},
+		{"Fix\n\nGenerated-by: Goose\nAssisted-by: Cursor", []string{"Goose", "Cursor"}},
+		{"Fix\n\nCo-authored-by: Claude\nCo-authored-by: Claude", []string{"Claude"}},
+		{"Fix\n\nCo-authored-by: Ada <ada@example.com>", nil},
+		{"Fix\n\nCo-authored-by: Claudette <c@example.com>", nil},
+		{"Fix\n\nReviewed-by: Claude", nil},
+	}
+	for _, tt := range tests {
+		if got := defaultAgentDetector.detect(tt.message, "", ""); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("detect(%q) = %v, want %v", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestDetectAgentsCustomRules(t *testing.T) {
+	d, err := compileAgentRules(AgentRules{
+		Names:    []string{"Deploy Bot", "renovate[bot]"},
+		Trailers: []string{"Signed-off-by"},
+		Emails: map[string]string{
+			"*@bots.example.com":    "Internal bot",
+			"noreply@anthropic.com": "Claude",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		message, author, committer string
+		want                       []string
+	}{
+		{"Fix\n\nSigned-off-by: deploy bot <d@example.com>", "", "", []string{"Deploy Bot"}},
+		{"Fix\n\nSigned-off-by: Someone <noreply@anthropic.com>", "", "", []string{"Claude"}},
+		{"Fix\n\nCo-authored-by: Claude <x@example.com>", "", "", nil},
+		{"Fix\n\nSigned-off-by: renovate[bot] <29139614+renovate[bot]@users.noreply.github.com>", "", "", []string{"renovate[bot]"}},
+		{"Fix\n\nSigned-off-by: renovate[bot]", "", "", []string{"renovate[bot]"}},
+		{"Fix\n\nSigned-off-by: renovate[bot]x", "", "", nil},
+		{"Fix", "ci@bots.example.com", "ada@example.com", []string{"Internal bot"}},
+		{"Fix", "ada@example.com", "CI@Bots.Example.com", []string{"Internal bot"}},
+		{"Fix\n\nSigned-off-by: Deploy Bot", "ci@bots.example.com", "", []string{"Deploy Bot", "Internal bot"}},
+	}
+	for _, tt := range tests {
+		if got := d.detect(tt.message, tt.author, tt.committer); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("detect(%q, %q, %q) = %v, want %v", tt.message, tt.author, tt.committer, got, tt.want)
+		}
+	}
+}
+
+func TestEmailPatternsAreLiteralButForStars(t *testing.T) {
+	d, err := compileAgentRules(AgentRules{Emails: map[string]string{
+		"devin-ai-integration[bot]@users.noreply.github.com": "Devin",
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := d.emailAgent("devin-ai-integration[bot]@users.noreply.github.com"); got != "Devin" {
+		t.Errorf("bot email matched %q", got)
+	}
+	if got := d.emailAgent("devin-ai-integrationb@users.noreply.github.com"); got != "" {
+		t.Errorf("brackets should not form a character class, matched %q", got)
+	}
+}
+
+func TestSetAgentRulesRejectsIncompleteEmails(t *testing.T) {
+	c := New(gittest.New(t))
+	if err := c.SetAgentRules(AgentRules{Emails: map[string]string{"*@bots.example.com": ""}}); err == nil {
+		t.Fatal("expected an error for a pattern without an agent name")
+	}
+	if c.agentDetector() != defaultAgentDetector {
+		t.Error("a rejected rule set should leave the defaults in place")
+	}
+}
+
+func TestListCommitsUsesAgentRules(t *testing.T) {
+	r := originRunner(t)
+	r.On("symbolic-ref", "refs/remotes/origin/HEAD").Return("refs/remotes/origin/main\n")
+	r.On("log", "main..feat", "-z", "-M", "--numstat", commitLogFormat, "--").
+		Return("\x1eaaa\x1fBump deps\x1fci\x1fci@bots.example.com\x1fci@bots.example.com\x1f1 hour ago\x1f\x1f\x00")
+	r.On("rev-parse", "--verify", "origin/feat").Fail("fatal: Needed a single revision")
+	c := New(r)
+	if err := c.SetAgentRules(AgentRules{Emails: map[string]string{"*@bots.example.com": "CI"}}); err != nil {
+		t.Fatal(err)
+	}
+	commits, err := c.ListCommits("/src/app", "feat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 1 || !reflect.DeepEqual(commits[0].AIAgents, []string{"CI"}) {
+		t.Errorf("commits = %+v", commits)
+	}
+}
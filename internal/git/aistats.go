@@ -0,0 +1,206 @@
+package git
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Contribution counts commits and the lines they added and removed.
+type Contribution struct {
+	Commits   int
+	Additions int
+	Deletions int
+}
+
+func (c *Contribution) add(commit Commit) {
+	c.Commits++
+	c.Additions += commit.Additions
+	c.Deletions += commit.Deletions
+}
+
+// Lines is the number of lines added and removed.
+func (c Contribution) Lines() int {
+	return c.Additions + c.Deletions
+}
+
+// AIStats splits a set of commits between AI agents and humans.
+type AIStats struct {
+	Total Contribution
+	Human Contribution
+	// AI counts every commit made with at least one agent once. Agents
+	// splits them by agent, so a commit made with two agents counts
+	// towards both.
+	AI     Contribution
+	Agents map[string]Contribution
+}
+
+// SummarizeAI adds up commits by who made them.
+func SummarizeAI(commits []Commit) AIStats {
+	var s AIStats
+	for _, c := range commits {
+		s.Total.add(c)
+		if len(c.AIAgents) == 0 {
+			s.Human.add(c)
+			continue
+		}
+		s.AI.add(c)
+		if s.Agents == nil {
+			s.Agents = make(map[string]Contribution)
+		}
+		for _, name := range c.AIAgents {
+			a := s.Agents[name]
+			a.add(c)
+			s.Agents[name] = a
+		}
+	}
+	return s
+}
+
+// Merge adds the commits counted in o to s.
+func (s *AIStats) Merge(o AIStats) {
+	merge := func(into *Contribution, c Contribution) {
+		into.Commits += c.Commits
+		into.Additions += c.Additions
+		into.Deletions += c.Deletions
+	}
+	merge(&s.Total, o.Total)
+	merge(&s.Human, o.Human)
+	merge(&s.AI, o.AI)
+	for name, c := range o.Agents {
+		if s.Agents == nil {
+			s.Agents = make(map[string]Contribution)
+		}
+		a := s.Agents[name]
+		merge(&a, c)
+		s.Agents[name] = a
+	}
+}
+
+// CommitShare returns the percentage of all commits that c accounts for.
+func (s AIStats) CommitShare(c Contribution) int {
+	return percent(c.Commits, s.Total.Commits)
+}
+
+// LineShare returns the percentage of all added and removed lines that c
+// accounts for.
+func (s AIStats) LineShare(c Contribution) int {
+	return percent(c.Lines(), s.Total.Lines())
+}
+
+// AgentNames lists the agents by number of commits, most active first.
+func (s AIStats) AgentNames() []string {
+	names := make([]string, 0, len(s.Agents))
+	for name := range s.Agents {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := s.Agents[names[i]], s.Agents[names[j]]
+		if a.Commits != b.Commits {
+			return a.Commits > b.Commits
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+func percent(n, total int) int {
+	if total == 0 {
+		return 0
+	}
+	return (100*n + total/2) / total
+}
+
+// aiStats summarises the commits listed by `git log` with the given
+// revision arguments.
+func (c *Client) aiStats(repoPath string, revs ...string) (AIStats, error) {
+	args := append([]string{"log"}, revs...)
+	args = append(args, "-z", "-M", "--numstat", commitLogFormat, "--")
+	stream, err := c.runner.Stream(repoPath, args...)
+	if err != nil {
+		return AIStats{}, err
+	}
+	commits, parseErr := parseCommitLog(stream, c.agentDetector())
+	if err := stream.Close(); err != nil {
+		return AIStats{}, err
+	}
+	if parseErr != nil {
+		return AIStats{}, parseErr
+	}
+	return SummarizeAI(commits), nil
+}
+
+// aiCache keeps the AI stats of worktree branches from the last
+// ListWorktrees of each repository, keyed by the tips of the default
+// branch and the worktree, so a refresh only reads the log of branches
+// that moved. defaults does the same for RepoAIStats, keyed by the tip of
+// the default branch and the commit limit.
+type aiCache struct {
+	mu       sync.Mutex
+	repos    map[string]map[string]AIStats
+	defaults map[string]cachedAIStats
+}
+
+type cachedAIStats struct {
+	key   string
+	stats AIStats
+}
+
+func (a *aiCache) load(repoPath string) map[string]AIStats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.repos[repoPath]
+}
+
+func (a *aiCache) store(repoPath string, stats map[string]AIStats) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.repos == nil {
+		a.repos = make(map[string]map[string]AIStats)
+	}
+	a.repos[repoPath] = stats
+}
+
+func (a *aiCache) loadDefault(repoPath, key string) (AIStats, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	c, ok := a.defaults[repoPath]
+	if !ok || c.key != key {
+		return AIStats{}, false
+	}
+	return c.stats, true
+}
+
+func (a *aiCache) storeDefault(repoPath, key string, stats AIStats) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.defaults == nil {
+		a.defaults = make(map[string]cachedAIStats)
+	}
+	a.defaults[repoPath] = cachedAIStats{key: key, stats: stats}
+}
+
+func (a *aiCache) reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.repos = nil
+	a.defaults = nil
+}
+
+// RepoAIStats summarises the newest limit commits of the default branch,
+// taken from the base remote when it has been fetched. The result is
+// reused until the default branch moves.
+func (c *Client) RepoAIStats(repoPath string, limit int) (AIStats, error) {
+	remotes := c.Remotes(repoPath)
+	defaultBranch := c.detectDefaultBranch(repoPath, remotes.Base)
+	ref, tip := c.defaultTip(repoPath, remotes.Base, defaultBranch)
+	key := fmt.Sprintf("%s~%d", tip, limit)
+	if s, ok := c.ai.loadDefault(repoPath, key); ok {
+		return s, nil
+	}
+	s, err := c.aiStats(repoPath, fmt.Sprintf("--max-count=%d", limit), ref)
+	if err == nil && tip != "" {
+		c.ai.storeDefault(repoPath, key, s)
+	}
+	return s, err
+}
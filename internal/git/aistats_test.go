@@ -0,0 +1,108 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSummarizeAI(t *testing.T) {
+	s := SummarizeAI([]Commit{
+		{Additions: 10, Deletions: 2, AIAgents: []string{"Claude"}},
+		{Additions: 4, AIAgents: []string{"Claude", "Copilot"}},
+		{Additions: 1, Deletions: 1, AIAgents: []string{"Copilot"}},
+		{Additions: 2},
+	})
+	if s.Total != (Contribution{Commits: 4, Additions: 17, Deletions: 3}) {
+		t.Errorf("Total = %+v", s.Total)
+	}
+	if s.Human != (Contribution{Commits: 1, Additions: 2}) {
+		t.Errorf("Human = %+v", s.Human)
+	}
+	if s.AI != (Contribution{Commits: 3, Additions: 15, Deletions: 3}) {
+		t.Errorf("AI = %+v", s.AI)
+	}
+	if c := s.Agents["Copilot"]; c != (Contribution{Commits: 2, Additions: 5, Deletions: 1}) {
+		t.Errorf("Copilot = %+v", c)
+	}
+	if got := s.CommitShare(s.AI); got != 75 {
+		t.Errorf("AI commit share = %d", got)
+	}
+	if got := s.LineShare(s.Human); got != 10 {
+		t.Errorf("human line share = %d", got)
+	}
+	if got := s.LineShare(s.Agents["Claude"]); got != 80 {
+		t.Errorf("Claude line share = %d", got)
+	}
+	if got := s.AgentNames(); !reflect.DeepEqual(got, []string{"Claude", "Copilot"}) {
+		t.Errorf("AgentNames = %v", got)
+	}
+}
+
+func TestAIStatsMergeAndEmpty(t *testing.T) {
+	var s AIStats
+	if s.CommitShare(s.AI) != 0 || s.LineShare(s.AI) != 0 {
+		t.Error("shares of nothing should be 0")
+	}
+	s.Merge(SummarizeAI([]Commit{{Additions: 3, AIAgents: []string{"Amp"}}}))
+	s.Merge(SummarizeAI([]Commit{{Additions: 1}, {Deletions: 2, AIAgents: []string{"Amp"}}}))
+	if s.Total.Commits != 3 || s.AI.Commits != 2 || s.Human.Commits != 1 {
+		t.Errorf("merged = %+v", s)
+	}
+	if c := s.Agents["Amp"]; c != (Contribution{Commits: 2, Additions: 3, Deletions: 2}) {
+		t.Errorf("Amp = %+v", c)
+	}
+}
+
+func TestRepoAIStats(t *testing.T) {
+	r := originRunner(t)
+	r.On("symbolic-ref", "refs/remotes/origin/HEAD").Return("refs/remotes/origin/main\n")
+	r.On("rev-parse", "--verify", "-q", "refs/remotes/origin/main").Return("aaa\n")
+	r.On("log", "--max-count=50", "origin/main", "-z", "-M", "--numstat", commitLogFormat, "--").
+		Return("\x1eaaa\x1fAdd parser\x1fAda\x1fada@example.com\x1fada@example.com\x1f2 hours ago\x1f\x1fCo-authored-by: Claude <noreply@anthropic.com>\n\x00" +
+			"\n4\t1\tparser.go\x00" +
+			"\x1ebbb\x1fInitial\x1fBob\x1fbob@example.com\x1fbob@example.com\x1f3 days ago\x1f\x1f\x00" +
+			"\n1\t0\tgo.mod\x00")
+
+	s, err := New(r).RepoAIStats("/src/app", 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Total.Commits != 2 || s.Agents["Claude"] != (Contribution{Commits: 1, Additions: 4, Deletions: 1}) {
+		t.Errorf("stats = %+v", s)
+	}
+}
+
+func TestRepoAIStatsCachedByTip(t *testing.T) {
+	r := originRunner(t)
+	r.On("symbolic-ref", "refs/remotes/origin/HEAD").Return("refs/remotes/origin/main\n")
+	r.On("rev-parse", "--verify", "-q", "refs/remotes/origin/main").Return("aaa\n").
+		On("rev-parse", "--verify", "-q", "refs/remotes/origin/main").Return("aaa\n").
+		On("rev-parse", "--verify", "-q", "refs/remotes/origin/main").Return("ccc\n")
+	r.On("log", "--max-count=50", "origin/main", "-z", "-M", "--numstat", commitLogFormat, "--").
+		Return("\x1eaaa\x1fAdd parser\x1fAda\x1fada@example.com\x1fada@example.com\x1f2 hours ago\x1f\x1f\x00\n4\t1\tparser.go\x00")
+	c := New(r)
+	logs := func() int {
+		n := 0
+		for _, call := range r.Calls() {
+			if call.Args[0] == "log" {
+				n++
+			}
+		}
+		return n
+	}
+
+	for range 2 {
+		if _, err := c.RepoAIStats("/src/app", 50); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := logs(); n != 1 {
+		t.Fatalf("log read %d times, want once while the default branch stayed put", n)
+	}
+	if _, err := c.RepoAIStats("/src/app", 50); err != nil {
+		t.Fatal(err)
+	}
+	if n := logs(); n != 2 {
+		t.Errorf("log read %d times, want again after the default branch moved", n)
+	}
+}
@@ -0,0 +1,37 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BareDir returns the git directory of repoPath when it is a bare
+// repository: either the repository itself ("app.git") or the directory a
+// ".git" file points to (the "app/.bare" layout).
+func (c *Client) BareDir(repoPath string) (string, bool) {
+	out, err := c.runner.Output(repoPath, "rev-parse", "--is-bare-repository", "--absolute-git-dir")
+	if err != nil {
+		return "", false
+	}
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(lines) != 2 || lines[0] != "true" {
+		return "", false
+	}
+	return lines[1], true
+}
+
+// workTreeOn returns the worktree that has branch checked out. A bare
+// repository has no working tree of its own, so operations that need one,
+// like merging into the default branch, run there instead.
+func (c *Client) workTreeOn(repoPath, branch string) (string, error) {
+	out, err := c.runner.Output(repoPath, "worktree", "list", "--porcelain")
+	if err != nil {
+		return "", err
+	}
+	for _, wt := range parseWorktrees(string(out)) {
+		if !wt.Bare && wt.Branch == branch {
+			return wt.Path, nil
+		}
+	}
+	return "", fmt.Errorf("%s is not checked out in any worktree", branch)
+}
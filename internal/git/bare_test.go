@@ -0,0 +1,95 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/marcellolins/mossy/internal/git/gittest"
+)
+
+func TestBareDir(t *testing.T) {
+	r := gittest.New(t)
+	r.On("rev-parse", "--is-bare-repository", "--absolute-git-dir").Return("true\n/src/app/.bare\n")
+	if dir, ok := New(r).BareDir("/src/app"); !ok || dir != "/src/app/.bare" {
+		t.Fatalf("BareDir = %q, %v", dir, ok)
+	}
+
+	r = gittest.New(t)
+	r.On("rev-parse", "--is-bare-repository", "--absolute-git-dir").Return("false\n/src/app/.git\n")
+	if _, ok := New(r).BareDir("/src/app"); ok {
+		t.Fatal("a normal clone should not be bare")
+	}
+}
+
+func TestListWorktreesBare(t *testing.T) {
+	r := gittest.New(t)
+	r.On("worktree", "list", "--porcelain").Return(
+		"worktree /src/app.git\nbare\n\n" +
+			"worktree /src/main\nHEAD aaa\nbranch refs/heads/main\n\n" +
+			"worktree /src/feat\nHEAD bbb\nbranch refs/heads/feat\n\n")
+	r.On("remote").Return("origin\n")
+	r.On("config", "--get", "remote.pushDefault").Fail("")
+	r.On("symbolic-ref", "refs/remotes/origin/HEAD").Fail("fatal: not a symbolic ref")
+	r.On("rev-parse", "--verify", "refs/heads/main").Return("aaa\n")
+	r.On("rev-parse", "--verify", "-q", "refs/remotes/origin/main").Fail("")
+	r.On("diff", "--numstat", "main...bbb").Return("2\t1\tmain.go\n5\t0\tREADME.md\n")
+	r.On("log", "main..bbb", "-z", "-M", "--numstat", commitLogFormat, "--").
+		Return("\x1ebbb\x1fAdd docs\x1fAda\x1fada@example.com\x1fada@example.com\x1f1 hour ago\x1f\x1f\x00" +
+			"\n2\t1\tmain.go\x005\t0\tREADME.md\x00")
+	r.On("rev-list", "--left-right", "--count", "main...bbb").Return("0\t1\n")
+	// Status is asked for /src/main first, then /src/feat.
+	r.On("status", "--porcelain=v2", "--branch", "-z").
+		Return("# branch.head main\x00").
+		On("status", "--porcelain=v2", "--branch", "-z").
+		Return("# branch.head feat\x001 .M N... 100644 100644 100644 aaa aaa main.go\x00")
+	r.On("rev-parse", "--absolute-git-dir").Return(t.TempDir() + "\n")
+
+	wts, err := New(r).ListWorktrees("/src/app.git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Every worktree of a bare repository is a linked one, including the
+	// one on the default branch.
+	if len(wts) != 2 || wts[0].Path != "/src/main" || wts[1].Path != "/src/feat" {
+		t.Fatalf("ListWorktrees = %+v", wts)
+	}
+	main, feat := wts[0], wts[1]
+	if main.Main || feat.Main {
+		t.Error("no worktree of a bare repository is the main one")
+	}
+	if main.Additions != 0 || main.DefaultAhead != 0 || main.AI.Total.Commits != 0 {
+		t.Errorf("worktree on the default branch should have no stats: %+v", main)
+	}
+	if feat.Additions != 7 || feat.Deletions != 1 {
+		t.Errorf("feat lines = +%d -%d, want +7 -1", feat.Additions, feat.Deletions)
+	}
+	if feat.DefaultAhead != 1 || feat.DefaultBehind != 0 {
+		t.Errorf("feat sync = ↑%d ↓%d, want ↑1 ↓0", feat.DefaultAhead, feat.DefaultBehind)
+	}
+	if feat.AI.Total.Commits != 1 || feat.AI.Human.Commits != 1 {
+		t.Errorf("feat AI stats = %+v", feat.AI)
+	}
+	if feat.Status.Unstaged != 1 {
+		t.Errorf("feat status = %+v", feat.Status)
+	}
+}
+
+func TestMergeIntoDefaultBare(t *testing.T) {
+	r := originRunner(t)
+	r.On("symbolic-ref", "refs/remotes/origin/HEAD").Return("refs/remotes/origin/main\n")
+	r.On("rev-parse", "--is-bare-repository", "--absolute-git-dir").Return("true\n/src/app.git\n")
+	r.On("worktree", "list", "--porcelain").Return(
+		"worktree /src/app.git\nbare\n\nworktree /src/main\nHEAD aaa\nbranch refs/heads/main\n\n")
+	r.On("symbolic-ref", "--short", "-q", "HEAD").Return("main\n")
+	r.On("status", "--porcelain=v2", "--branch", "-z").Return("# branch.head main\x00")
+	r.On("rev-parse", "--absolute-git-dir").Return(t.TempDir() + "\n")
+	r.On("merge", "--ff-only", "feat").Return("")
+
+	if err := New(r).MergeIntoDefault("/src/app.git", "feat", MergeFastForward); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range r.Calls() {
+		if c.Args[0] == "merge" && c.Dir != "/src/main" {
+			t.Errorf("git %s ran in %s, want the default branch's worktree", c, c.Dir)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package git
+
+import "strings"
+
+// Branch is a local or remote-tracking branch as listed by for-each-ref.
+type Branch struct {
+	// Name is the short ref name, e.g. "feature/x" or "origin/feature/x".
+	Name     string
+	Remote   bool
+	Upstream string
+	// WorktreePath is set when a local branch is already checked out in
+	// some worktree, which means it cannot be checked out again.
+	WorktreePath string
+}
+
+// LocalName strips the remote prefix from a remote-tracking branch name.
+func (b Branch) LocalName() string {
+	if !b.Remote {
+		return b.Name
+	}
+	if _, rest, ok := strings.Cut(b.Name, "/"); ok {
+		return rest
+	}
+	return b.Name
+}
+
+const branchFormat = "--format=%(refname)%00%(refname:short)%00%(upstream:short)%00%(worktreepath)%00%(symref)"
+
+// ListBranches returns local branches followed by remote-tracking branches.
+func (c *Client) ListBranches(repoPath string) ([]Branch, error) {
+	out, err := c.runner.Output(repoPath, "for-each-ref", branchFormat, "refs/heads", "refs/remotes")
+	if err != nil {
+		return nil, err
+	}
+	return parseBranches(string(out)), nil
+}
+
+func parseBranches(output string) []Branch {
+	var branches []Branch
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) < 5 {
+			continue
+		}
+		// Skip symbolic refs such as origin/HEAD.
+		if fields[4] != "" {
+			continue
+		}
+		branches = append(branches, Branch{
+			Name:         fields[1],
+			Remote:       strings.HasPrefix(fields[0], "refs/remotes/"),
+			Upstream:     fields[2],
+			WorktreePath: fields[3],
+		})
+	}
+	return branches
+}
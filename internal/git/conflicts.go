@@ -0,0 +1,83 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConflictError is returned when a rebase or merge stops on conflicts and
+// is left in progress so they can be resolved.
+type ConflictError struct {
+	// Operation is "rebase" or "merge".
+	Operation string
+	Files     []string
+	Output    string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s conflicts in %d file(s)", e.Operation, len(e.Files))
+}
+
+// ConflictedFiles lists the unmerged paths in the worktree.
+func (c *Client) ConflictedFiles(wtPath string) ([]string, error) {
+	out, err := c.runner.Output(wtPath, "diff", "--name-only", "--diff-filter=U", "-z")
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, f := range strings.Split(string(out), "\x00") {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+// MarkResolved stages a file so git considers its conflict resolved.
+func (c *Client) MarkResolved(wtPath, file string) error {
+	if out, err := c.runner.CombinedOutput(wtPath, "add", "--", file); err != nil {
+		return fmt.Errorf("%s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// Continue resumes a paused rebase or concludes a paused merge without
+// opening an editor for the commit message. It returns a *ConflictError if
+// a rebase stops again on the next commit.
+func (c *Client) Continue(wtPath, operation string) error {
+	args := []string{"-c", "core.editor=true", "rebase", "--continue"}
+	if operation == "merge" {
+		args = []string{"-c", "core.editor=true", "commit", "--no-edit"}
+	}
+	out, err := c.runner.CombinedOutput(wtPath, args...)
+	if err == nil {
+		return nil
+	}
+	return c.conflictOr(wtPath, operation, out)
+}
+
+// RebaseSkip drops the commit that caused the current rebase conflict.
+func (c *Client) RebaseSkip(wtPath string) error {
+	out, err := c.runner.CombinedOutput(wtPath, "-c", "core.editor=true", "rebase", "--skip")
+	if err == nil {
+		return nil
+	}
+	return c.conflictOr(wtPath, "rebase", out)
+}
+
+// Abort restores the branch to where it was before the rebase or merge.
+func (c *Client) Abort(wtPath, operation string) error {
+	if out, err := c.runner.CombinedOutput(wtPath, operation, "--abort"); err != nil {
+		return fmt.Errorf("%s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// conflictOr turns a failed step into a *ConflictError when unmerged paths
+// remain, or a plain error carrying git's last line otherwise.
+func (c *Client) conflictOr(wtPath, operation string, out []byte) error {
+	if files, err := c.ConflictedFiles(wtPath); err == nil && len(files) > 0 {
+		return &ConflictError{Operation: operation, Files: files, Output: strings.TrimSpace(string(out))}
+	}
+	return fmt.Errorf("%s", lastLine(out))
+}
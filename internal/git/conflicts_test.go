@@ -0,0 +1,85 @@
+package git
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/marcellolins/mossy/internal/git/gittest"
+)
+
+func TestUpdateWorktreeKeepsConflicts(t *testing.T) {
+	r := originRunner(t)
+	r.On("symbolic-ref", "refs/remotes/origin/HEAD").Return("refs/remotes/origin/main\n")
+	r.On("fetch", "origin", "main").Return("")
+	r.On("rebase", "origin/main").Fail("CONFLICT (content): Merge conflict in main.go\n")
+	r.On("diff", "--name-only", "--diff-filter=U", "-z").Return("main.go\x00docs/a b.md\x00")
+
+	err := New(r).UpdateWorktree("/src/app", "/src/feat", UpdateOptions{KeepConflicts: true})
+	var conflictErr *ConflictError
+	if !errors.As(err, &conflictErr) || conflictErr.Operation != "rebase" {
+		t.Fatalf("UpdateWorktree error = %v, want *ConflictError", err)
+	}
+	if !reflect.DeepEqual(conflictErr.Files, []string{"main.go", "docs/a b.md"}) {
+		t.Errorf("Files = %v", conflictErr.Files)
+	}
+	if r.Called("rebase", "--abort") {
+		t.Fatal("rebase should be left in progress")
+	}
+}
+
+func TestUpdateWorktreeKeepConflictsReportsOtherFailures(t *testing.T) {
+	r := originRunner(t)
+	r.On("symbolic-ref", "refs/remotes/origin/HEAD").Return("refs/remotes/origin/main\n")
+	r.On("fetch", "origin", "main").Return("")
+	r.On("rebase", "origin/main").Fail("error: cannot rebase: You have unstaged changes.\nerror: Please commit or stash them.\n")
+	r.On("diff", "--name-only", "--diff-filter=U", "-z").Return("")
+	r.On("rev-parse", "--absolute-git-dir").Return(t.TempDir() + "\n")
+
+	err := New(r).UpdateWorktree("/src/app", "/src/feat", UpdateOptions{KeepConflicts: true})
+	if err == nil || errors.As(err, new(*ConflictError)) {
+		t.Fatalf("UpdateWorktree error = %v, want a plain error", err)
+	}
+	if want := "rebase failed: cannot rebase: You have unstaged changes. — commit or stash your changes, or update with autostash"; err.Error() != want {
+		t.Errorf("error = %q, want %q", err, want)
+	}
+	if r.Called("rebase", "--abort") {
+		t.Error("a rebase that never started should not be aborted")
+	}
+}
+
+func TestContinueRebase(t *testing.T) {
+	r := gittest.New(t)
+	r.On("-c", "core.editor=true", "rebase", "--continue").
+		Fail("CONFLICT (content): Merge conflict in b.go\n").
+		On("-c", "core.editor=true", "rebase", "--continue").
+		Return("Successfully rebased and updated refs/heads/feat.\n")
+	r.On("diff", "--name-only", "--diff-filter=U", "-z").Return("b.go\x00")
+
+	c := New(r)
+	var conflictErr *ConflictError
+	if err := c.Continue("/src/feat", "rebase"); !errors.As(err, &conflictErr) || conflictErr.Files[0] != "b.go" {
+		t.Fatalf("first continue = %v, want conflicts in b.go", err)
+	}
+	if err := c.Continue("/src/feat", "rebase"); err != nil {
+		t.Fatalf("second continue = %v", err)
+	}
+}
+
+func TestContinueMergeCommits(t *testing.T) {
+	r := gittest.New(t)
+	r.On("-c", "core.editor=true", "commit", "--no-edit").Return("")
+
+	if err := New(r).Continue("/src/feat", "merge"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMarkResolved(t *testing.T) {
+	r := gittest.New(t)
+	r.On("add", "--", "-odd name.go").Return("")
+
+	if err := New(r).MarkResolved("/src/feat", "-odd name.go"); err != nil {
+		t.Fatal(err)
+	}
+}
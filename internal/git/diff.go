@@ -0,0 +1,155 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Patch is a unified diff split into lines, with the position of every
+// file and hunk so a viewer can jump between them.
+type Patch struct {
+	Lines []string
+	Files []PatchFile
+}
+
+// PatchFile is one file's section of a Patch.
+type PatchFile struct {
+	// Path is the file's new path, or its old one when it was deleted.
+	// OldPath is only set for renames and copies.
+	Path    string
+	OldPath string
+	// Start is the index in Lines of the file's "diff --git" header and
+	// Hunks those of its "@@" hunk headers.
+	Start     int
+	Hunks     []int
+	Additions int
+	Deletions int
+	Binary    bool
+}
+
+// CommitDiff returns `git show` output for a commit: its header, a
+// diffstat and the patch, with renames detected.
+func (c *Client) CommitDiff(repoPath, hash string) (string, error) {
+	out, err := c.runner.CombinedOutput(repoPath, "show", "--no-color", "--stat", "--patch", "-M", hash, "--")
+	if err != nil {
+		return "", fmt.Errorf("%s", fatalLine(out))
+	}
+	return string(out), nil
+}
+
+// ParsePatch indexes the files and hunks of a unified diff. Text before
+// the first file, such as a commit header or diffstat, is kept in Lines.
+func ParsePatch(text string) Patch {
+	p := Patch{Lines: strings.Split(strings.TrimRight(text, "\n"), "\n")}
+	if text == "" {
+		p.Lines = nil
+	}
+	var f *PatchFile
+	inHunk := false
+	for i, line := range p.Lines {
+		if strings.HasPrefix(line, "diff --git ") || strings.HasPrefix(line, "diff --cc ") {
+			p.Files = append(p.Files, PatchFile{Path: headerPath(line), Start: i})
+			f = &p.Files[len(p.Files)-1]
+			inHunk = false
+			continue
+		}
+		if f == nil {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			f.Hunks = append(f.Hunks, i)
+			inHunk = true
+		case inHunk && strings.HasPrefix(line, "+"):
+			f.Additions++
+		case inHunk && strings.HasPrefix(line, "-"):
+			f.Deletions++
+		case inHunk:
+		case strings.HasPrefix(line, "rename from "), strings.HasPrefix(line, "copy from "):
+			_, from, _ := strings.Cut(line, " from ")
+			f.OldPath = unquotePath(from)
+		case strings.HasPrefix(line, "rename to "), strings.HasPrefix(line, "copy to "):
+			_, to, _ := strings.Cut(line, " to ")
+			f.Path = unquotePath(to)
+		case strings.HasPrefix(line, "+++ "):
+			// git appends a tab to paths that contain spaces.
+			path := unquotePath(strings.TrimSuffix(strings.TrimPrefix(line, "+++ "), "\t"))
+			if path != "/dev/null" {
+				f.Path = strings.TrimPrefix(path, "b/")
+			}
+		case strings.HasPrefix(line, "Binary files "):
+			f.Binary = true
+		}
+	}
+	return p
+}
+
+// headerPath guesses the path from a "diff --git a/x b/x" line. The
+// header is ambiguous when paths contain " b/", so the "+++" and rename
+// lines that follow take precedence.
+func headerPath(line string) string {
+	if rest, ok := strings.CutPrefix(line, "diff --cc "); ok {
+		return unquotePath(rest)
+	}
+	rest := strings.TrimPrefix(line, "diff --git ")
+	if strings.HasPrefix(rest, `"`) {
+		if i := strings.Index(rest[1:], `" `); i >= 0 {
+			return strings.TrimPrefix(unquotePath(rest[:i+2]), "a/")
+		}
+	}
+	// With an unchanged path the header is "a/<p> b/<p>".
+	if n := (len(rest) - 5) / 2; n > 0 && len(rest) == 2*n+5 && rest[2+n:2+n+3] == " b/" {
+		return rest[2 : 2+n]
+	}
+	if _, b, ok := strings.Cut(rest, " b/"); ok {
+		return b
+	}
+	return rest
+}
+
+// unquotePath undoes git's C-style quoting of paths with unusual
+// characters.
+func unquotePath(s string) string {
+	if strings.HasPrefix(s, `"`) {
+		if u, err := strconv.Unquote(s); err == nil {
+			return u
+		}
+	}
+	return s
+}
+
+// WorkingChanges are the uncommitted changes of a worktree.
+type WorkingChanges struct {
+	// Staged and Unstaged are the patches of `git diff --cached` and
+	// `git diff`.
+	Staged    string
+	Unstaged  string
+	Untracked []string
+}
+
+// WorkingChanges returns the staged and unstaged patches of a worktree and
+// the untracked files that are not ignored.
+func (c *Client) WorkingChanges(wtPath string) (WorkingChanges, error) {
+	var wc WorkingChanges
+	out, err := c.runner.CombinedOutput(wtPath, "diff", "--no-color", "-M", "--cached")
+	if err != nil {
+		return WorkingChanges{}, fmt.Errorf("%s", fatalLine(out))
+	}
+	wc.Staged = string(out)
+	out, err = c.runner.CombinedOutput(wtPath, "diff", "--no-color", "-M")
+	if err != nil {
+		return WorkingChanges{}, fmt.Errorf("%s", fatalLine(out))
+	}
+	wc.Unstaged = string(out)
+	out, err = c.runner.Output(wtPath, "ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return WorkingChanges{}, err
+	}
+	for _, path := range strings.Split(string(out), "\x00") {
+		if path != "" {
+			wc.Untracked = append(wc.Untracked, path)
+		}
+	}
+	return wc, nil
+}
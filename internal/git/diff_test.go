@@ -0,0 +1,122 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/marcellolins/mossy/internal/git/gittest"
+)
+
+// showOutput was captured from `git show --no-color --stat --patch -M` on
+// a throwaway repository.
+const showOutput = `commit c52466945ddd0b1b8afcf712338cbb409faccf46
+Author: a <a@b>
+Date:   Sat Oct 17 00:14:47 2026 +0000
+
+    change
+---
+ bin.dat                | Bin 2 -> 2 bytes
+ gone.txt               |   1 -
+ keep.txt               |   3 ++-
+ ren.txt => renamed.txt |   0
+ sp ace.txt             |   2 +-
+ "t\tab.txt"            |   1 +
+ 6 files changed, 4 insertions(+), 3 deletions(-)
+
+diff --git a/bin.dat b/bin.dat
+index bdc955b..8835708 100644
+Binary files a/bin.dat and b/bin.dat differ
+diff --git a/gone.txt b/gone.txt
+deleted file mode 100644
+index 587be6b..0000000
+--- a/gone.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-x
+diff --git a/keep.txt b/keep.txt
+index de98044..a7bc997 100644
+--- a/keep.txt
++++ b/keep.txt
+@@ -1,3 +1,4 @@
+ a
+-b
++B
+ c
++d
+diff --git a/ren.txt b/renamed.txt
+similarity index 100%
+rename from ren.txt
+rename to renamed.txt
+diff --git a/sp ace.txt b/sp ace.txt
+index 3367afd..3e75765 100644
+--- a/sp ace.txt	
++++ b/sp ace.txt	
+@@ -1 +1 @@
+-old
++new
+diff --git "a/t\tab.txt" "b/t\tab.txt"
+new file mode 100644
+index 0000000..8cc35a3
+--- /dev/null
++++ "b/t\tab.txt"
+@@ -0,0 +1 @@
++tab
+`
+
+func TestParsePatch(t *testing.T) {
+	p := ParsePatch(showOutput)
+	want := []PatchFile{
+		{Path: "bin.dat", Start: 14, Binary: true},
+		{Path: "gone.txt", Start: 17, Hunks: []int{22}, Deletions: 1},
+		{Path: "keep.txt", Start: 24, Hunks: []int{28}, Additions: 2, Deletions: 1},
+		{Path: "renamed.txt", OldPath: "ren.txt", Start: 34},
+		{Path: "sp ace.txt", Start: 38, Hunks: []int{42}, Additions: 1, Deletions: 1},
+		{Path: "t\tab.txt", Start: 45, Hunks: []int{50}, Additions: 1},
+	}
+	if !reflect.DeepEqual(p.Files, want) {
+		t.Fatalf("Files:\n got %+v\nwant %+v", p.Files, want)
+	}
+	if p.Lines[28] != "@@ -1,3 +1,4 @@" {
+		t.Errorf("Lines[28] = %q", p.Lines[28])
+	}
+}
+
+func TestParsePatchEmpty(t *testing.T) {
+	if p := ParsePatch(""); len(p.Lines) != 0 || len(p.Files) != 0 {
+		t.Fatalf("ParsePatch(\"\") = %+v", p)
+	}
+}
+
+func TestCommitDiff(t *testing.T) {
+	r := gittest.New(t)
+	r.On("show", "--no-color", "--stat", "--patch", "-M", "abc", "--").Return(showOutput)
+	out, err := New(r).CommitDiff("/src/app", "abc")
+	if err != nil || out != showOutput {
+		t.Fatalf("CommitDiff = %q, %v", out, err)
+	}
+
+	r = gittest.New(t)
+	r.On("show", "--no-color", "--stat", "--patch", "-M", "zzz", "--").Fail("fatal: bad object zzz\n")
+	if _, err := New(r).CommitDiff("/src/app", "zzz"); err == nil || err.Error() != "bad object zzz" {
+		t.Fatalf("CommitDiff error = %v", err)
+	}
+}
+
+func TestWorkingChanges(t *testing.T) {
+	r := gittest.New(t)
+	r.On("diff", "--no-color", "-M", "--cached").Return("diff --git a/a.go b/a.go\n")
+	r.On("diff", "--no-color", "-M").Return("")
+	r.On("ls-files", "--others", "--exclude-standard", "-z").Return("notes.txt\x00dir/new file.go\x00")
+
+	wc, err := New(r).WorkingChanges("/src/feat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := WorkingChanges{
+		Staged:    "diff --git a/a.go b/a.go\n",
+		Untracked: []string{"notes.txt", "dir/new file.go"},
+	}
+	if !reflect.DeepEqual(wc, want) {
+		t.Fatalf("WorkingChanges = %+v, want %+v", wc, want)
+	}
+}
@@ -0,0 +1,128 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fetchTimeout bounds a background fetch, so a remote that never answers
+// cannot keep later fetches from starting.
+const fetchTimeout = 3 * time.Minute
+
+// FetchProgress is one step of a fetch as reported by git --progress.
+type FetchProgress struct {
+	// Phase is e.g. "Fetching origin" or "Receiving objects".
+	Phase string
+	// Percent is the completion of Phase, or -1 when git gives none.
+	Percent int
+}
+
+var progressLine = regexp.MustCompile(`^(?:remote: )?([A-Za-z ]+):\s+(\d+)%`)
+
+// FetchAll fetches every remote of the repository and prunes deleted
+// remote branches. progress, if not nil, is called whenever git reports a
+// new phase or percentage.
+func (c *Client) FetchAll(repoPath string, progress func(FetchProgress)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+	env := c.nonInteractiveEnv(repoPath)
+	stream, err := c.runner.StreamCombined(ctx, repoPath, env, "fetch", "--all", "--prune", "--progress")
+	if err != nil {
+		return err
+	}
+	failure := parseFetchProgress(stream, progress)
+	if err := stream.Close(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			failure = fmt.Sprintf("timed out after %s", fetchTimeout)
+		}
+		if failure == "" {
+			failure = err.Error()
+		}
+		return fmt.Errorf("fetch failed: %s", failure)
+	}
+	return nil
+}
+
+// nonInteractiveEnv keeps a background git from prompting for
+// credentials on the terminal the UI owns: git's own prompt and the Git
+// Credential Manager are turned off, and ssh runs in batch mode, so a
+// missing credential fails the command instead. The ssh command is the
+// one git would pick itself, with BatchMode added.
+func (c *Client) nonInteractiveEnv(repoPath string) []string {
+	env := []string{"GIT_TERMINAL_PROMPT=0", "GCM_INTERACTIVE=never"}
+	ssh := os.Getenv("GIT_SSH_COMMAND")
+	if ssh == "" {
+		if out, err := c.runner.Output(repoPath, "config", "--get", "core.sshCommand"); err == nil {
+			ssh = strings.TrimSpace(string(out))
+		}
+	}
+	if ssh == "" {
+		if os.Getenv("GIT_SSH") != "" {
+			// A custom ssh program may not take -o; leave it alone.
+			return env
+		}
+		ssh = "ssh"
+	}
+	return append(env, "GIT_SSH_COMMAND="+ssh+" -o BatchMode=yes")
+}
+
+// parseFetchProgress reads git's progress output, which rewrites lines in
+// place with \r, and returns the reason it failed, if any.
+func parseFetchProgress(r io.Reader, progress func(FetchProgress)) string {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(scanProgressLines)
+	var last FetchProgress
+	var failure string
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		var p FetchProgress
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "fatal: "):
+			failure = strings.TrimPrefix(line, "fatal: ")
+			continue
+		case strings.HasPrefix(line, "error: "):
+			// "error: could not fetch origin" follows the fatal line that
+			// explains why, so only use it when there is nothing better.
+			if failure == "" {
+				failure = strings.TrimPrefix(line, "error: ")
+			}
+			continue
+		case strings.HasPrefix(line, "Fetching "):
+			p = FetchProgress{Phase: line, Percent: -1}
+		default:
+			m := progressLine.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			pct, _ := strconv.Atoi(m[2])
+			p = FetchProgress{Phase: m[1], Percent: pct}
+		}
+		if p != last && progress != nil {
+			progress(p)
+		}
+		last = p
+	}
+	return failure
+}
+
+// scanProgressLines is bufio.ScanLines that also splits on \r.
+func scanProgressLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
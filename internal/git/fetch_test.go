@@ -0,0 +1,87 @@
+package git
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/marcellolins/mossy/internal/git/gittest"
+)
+
+const fetchOutput = "Fetching origin\n" +
+	"remote: Counting objects:  50% (1/2)        \rremote: Counting objects: 100% (2/2)        \r" +
+	"remote: Counting objects: 100% (2/2), done.        \n" +
+	"Receiving objects:  33% (1/3)\rReceiving objects: 100% (3/3), done.\n" +
+	"From example.com:app\n * [new branch]      other      -> origin/other\n" +
+	"Fetching upstream\n"
+
+func TestParseFetchProgress(t *testing.T) {
+	var got []FetchProgress
+	failure := parseFetchProgress(strings.NewReader(fetchOutput), func(p FetchProgress) {
+		got = append(got, p)
+	})
+	want := []FetchProgress{
+		{Phase: "Fetching origin", Percent: -1},
+		{Phase: "Counting objects", Percent: 50},
+		{Phase: "Counting objects", Percent: 100},
+		{Phase: "Receiving objects", Percent: 33},
+		{Phase: "Receiving objects", Percent: 100},
+		{Phase: "Fetching upstream", Percent: -1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("progress:\n got %+v\nwant %+v", got, want)
+	}
+	if failure != "" {
+		t.Errorf("failure = %q, want none", failure)
+	}
+}
+
+func TestFetchAll(t *testing.T) {
+	t.Setenv("GIT_SSH_COMMAND", "")
+	r := gittest.New(t)
+	r.On("config", "--get", "core.sshCommand").Fail("")
+	r.On("fetch", "--all", "--prune", "--progress").Return(fetchOutput)
+
+	calls := 0
+	if err := New(r).FetchAll("/src/app", func(FetchProgress) { calls++ }); err != nil {
+		t.Fatal(err)
+	}
+	if calls == 0 {
+		t.Error("expected progress callbacks")
+	}
+	fetch := r.Calls()[len(r.Calls())-1]
+	want := []string{"GIT_TERMINAL_PROMPT=0", "GCM_INTERACTIVE=never", "GIT_SSH_COMMAND=ssh -o BatchMode=yes"}
+	if !reflect.DeepEqual(fetch.Env, want) {
+		t.Errorf("fetch env = %q, want %q", fetch.Env, want)
+	}
+}
+
+func TestNonInteractiveEnvKeepsSSHCommand(t *testing.T) {
+	t.Setenv("GIT_SSH_COMMAND", "")
+	r := gittest.New(t)
+	r.On("config", "--get", "core.sshCommand").Return("ssh -i ~/.ssh/work\n")
+
+	env := New(r).nonInteractiveEnv("/src/app")
+	if got := env[len(env)-1]; got != "GIT_SSH_COMMAND=ssh -i ~/.ssh/work -o BatchMode=yes" {
+		t.Errorf("ssh command = %q", got)
+	}
+
+	t.Setenv("GIT_SSH_COMMAND", "ssh -v")
+	env = New(r).nonInteractiveEnv("/src/app")
+	if got := env[len(env)-1]; got != "GIT_SSH_COMMAND=ssh -v -o BatchMode=yes" {
+		t.Errorf("ssh command from the environment = %q", got)
+	}
+}
+
+func TestFetchAllFailure(t *testing.T) {
+	r := gittest.New(t)
+	r.On("config", "--get", "core.sshCommand").Fail("")
+	r.On("fetch", "--all", "--prune", "--progress").
+		Fail("Fetching origin\nfatal: unable to access 'https://example.com/app.git/': Could not resolve host\n" +
+			"error: could not fetch origin\n")
+
+	err := New(r).FetchAll("/src/app", nil)
+	if err == nil || err.Error() != "fetch failed: unable to access 'https://example.com/app.git/': Could not resolve host" {
+		t.Fatalf("FetchAll error = %v", err)
+	}
+}
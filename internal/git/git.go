@@ -1,23 +1,59 @@
 package git
 
 import (
+	"bufio"
 	"fmt"
-	"os/exec"
+	"io"
+	"os"
 	"path/filepath"
-	"regexp"
 	"strconv"
 	"strings"
 )
 
-var aiAgentPattern = regexp.MustCompile(`(?im)Co-authored-by:\s+(Copilot|Goose|Claude|Cursor|Amp)\b`)
+// Client runs git operations for mossy. All commands go through the
+// Runner so they can be scripted in tests.
+type Client struct {
+	runner  Runner
+	remotes remoteSettings
+	agents  agentSettings
+	ai      aiCache
+}
+
+// New returns a Client that executes commands with the given Runner.
+func New(runner Runner) *Client {
+	return &Client{runner: runner}
+}
+
+// NewClient returns a Client backed by the git binary on PATH.
+func NewClient() *Client {
+	return New(ExecRunner{})
+}
 
 type Worktree struct {
-	Path      string
-	HEAD      string
-	Branch    string
+	Path   string
+	HEAD   string
+	Branch string
+	// Bare marks the entry of a bare repository, which has no working
+	// tree. Main marks the working tree of a non-bare repository.
 	Bare      bool
+	Main      bool
 	Additions int
 	Deletions int
+	Status    Status
+	// DefaultAhead and DefaultBehind count commits relative to the default
+	// branch (its remote-tracking ref when one exists).
+	DefaultAhead  int
+	DefaultBehind int
+	// AI splits the branch's commits that are not on the default branch
+	// between AI agents and humans.
+	AI AIStats
+	// Locked worktrees are protected from pruning and removal; LockReason
+	// is empty when none was given.
+	Locked     bool
+	LockReason string
+	// Prunable worktrees have lost their directory; PruneReason says why.
+	Prunable    bool
+	PruneReason string
 }
 
 type Commit struct {
@@ -32,52 +68,193 @@ type Commit struct {
 	Additions int
 	Deletions int
 	AIAgents  []string
+	Trailers  Trailers
+	// CoAuthors are the Co-authored-by trailers that are not AI agents.
+	CoAuthors []string
+	// Issues are the issues the commit refers to, e.g. "#12".
+	Issues []string
 }
 
-func ListWorktrees(repoPath string) ([]Worktree, error) {
-	cmd := exec.Command("git", "worktree", "list", "--porcelain")
-	cmd.Dir = repoPath
-	out, err := cmd.Output()
+func (c *Client) ListWorktrees(repoPath string) ([]Worktree, error) {
+	out, err := c.runner.Output(repoPath, "worktree", "list", "--porcelain")
 	if err != nil {
 		return nil, err
 	}
-	all := parseWorktrees(string(out))
-	if len(all) > 0 {
-		all = all[1:]
+	var all []Worktree
+	for i, wt := range parseWorktrees(string(out)) {
+		// git lists the main worktree first, or the repository itself
+		// when it is bare. A bare entry has no working tree to show.
+		if wt.Bare {
+			continue
+		}
+		wt.Main = i == 0
+		all = append(all, wt)
 	}
-	defaultBranch := detectDefaultBranch(repoPath)
+	// Each listing is a refresh: read the remotes again, once.
+	c.forgetRemotes(repoPath)
+	remotes := c.Remotes(repoPath)
+	defaultBranch := c.detectDefaultBranch(repoPath, remotes.Base)
+	defaultRef := c.defaultRef(repoPath, remotes.Base, defaultBranch)
+	// AI stats only change when the branch or the default branch moves.
+	defaultTip := c.branchTip(repoPath, defaultBranch, all)
+	cachedAI, nextAI := c.ai.load(repoPath), make(map[string]AIStats)
 	for i := range all {
 		if all[i].Branch != "" && all[i].Branch != defaultBranch && all[i].Branch != "(detached)" {
-			a, d := diffStats(repoPath, defaultBranch, all[i].HEAD)
+			a, d := c.diffStats(repoPath, defaultBranch, all[i].HEAD)
 			all[i].Additions = a
 			all[i].Deletions = d
+			key := defaultTip + ".." + all[i].HEAD
+			if ai, ok := cachedAI[key]; ok {
+				all[i].AI = ai
+				nextAI[key] = ai
+			} else if ai, err := c.aiStats(repoPath, defaultBranch+".."+all[i].HEAD); err == nil {
+				all[i].AI = ai
+				nextAI[key] = ai
+			}
+		}
+		if all[i].HEAD != "" && all[i].Branch != defaultBranch {
+			all[i].DefaultAhead, all[i].DefaultBehind = c.aheadBehind(repoPath, defaultRef, all[i].HEAD)
+		}
+		if all[i].Prunable {
+			continue
+		}
+		if st, err := c.Status(all[i].Path); err == nil {
+			all[i].Status = st
 		}
 	}
+	c.ai.store(repoPath, nextAI)
 	return all, nil
 }
 
-func AddWorktree(repoPath, name, branch string) error {
-	wtPath := filepath.Join(filepath.Dir(repoPath), name)
-	cmd := exec.Command("git", "worktree", "add", wtPath, "-b", branch)
-	cmd.Dir = repoPath
-	out, err := cmd.CombinedOutput()
+// branchTip returns the commit a local branch points at, taken from the
+// worktree that has it checked out when there is one. It is empty when the
+// branch does not exist.
+func (c *Client) branchTip(repoPath, branch string, worktrees []Worktree) string {
+	for _, wt := range worktrees {
+		if wt.Branch == branch {
+			return wt.HEAD
+		}
+	}
+	out, err := c.runner.Output(repoPath, "rev-parse", "--verify", "-q", "refs/heads/"+branch)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// AddMode selects where the branch of a new worktree comes from.
+type AddMode int
+
+const (
+	// AddNewBranch creates Branch as a new branch.
+	AddNewBranch AddMode = iota
+	// AddExistingBranch checks out an existing local Branch.
+	AddExistingBranch
+	// AddRemoteBranch creates a local branch tracking the remote-tracking
+	// Branch (e.g. "origin/feature/x" or "upstream/feature/x").
+	AddRemoteBranch
+)
+
+// BaseKind selects the start point of a new branch.
+type BaseKind int
+
+const (
+	// BaseDefaultBranch starts from the local default branch.
+	BaseDefaultBranch BaseKind = iota
+	// BaseRemoteDefault fetches the default branch from the base remote
+	// and starts from the fetched commit, which also works in bare clones
+	// whose fetches do not update <remote>/<default>.
+	BaseRemoteDefault
+	// BaseRef starts from Base.Ref: any branch, tag or commit.
+	BaseRef
+)
+
+type Base struct {
+	Kind BaseKind
+	Ref  string
+}
+
+type AddWorktreeOptions struct {
+	// Path is where the worktree is created; missing parent directories
+	// are created first.
+	Path   string
+	Name   string
+	Branch string
+	Mode   AddMode
+	// Base is only used with AddNewBranch.
+	Base Base
+}
+
+func (c *Client) AddWorktree(repoPath string, opts AddWorktreeOptions) error {
+	wtPath := opts.Path
+	if err := os.MkdirAll(filepath.Dir(wtPath), 0o755); err != nil {
+		return err
+	}
+	args := []string{"worktree", "add"}
+	branch := opts.Branch
+	switch opts.Mode {
+	case AddExistingBranch:
+		args = append(args, wtPath, opts.Branch)
+	case AddRemoteBranch:
+		branch = Branch{Name: opts.Branch, Remote: true}.LocalName()
+		args = append(args, "--track", "-b", branch, wtPath, opts.Branch)
+	default:
+		start, err := c.resolveBase(repoPath, opts.Base)
+		if err != nil {
+			return err
+		}
+		// --no-track keeps the new branch from adopting the base's
+		// upstream when starting from a remote-tracking ref.
+		args = append(args, "--no-track", "-b", opts.Branch, wtPath, start)
+	}
+	out, err := c.runner.CombinedOutput(repoPath, args...)
 	if err != nil {
-		return parseWorktreeError(string(out), name, branch)
+		return parseWorktreeError(string(out), opts.Name, branch)
 	}
 	return nil
 }
 
-func RemoveWorktree(repoPath, wtPath, branch string, deleteBranch bool) error {
-	cmd := exec.Command("git", "worktree", "remove", wtPath)
-	cmd.Dir = repoPath
-	out, err := cmd.CombinedOutput()
+// resolveBase turns a Base into a revision for `git worktree add`.
+func (c *Client) resolveBase(repoPath string, base Base) (string, error) {
+	switch base.Kind {
+	case BaseRemoteDefault:
+		remote := c.Remotes(repoPath).Base
+		defaultBranch := c.detectDefaultBranch(repoPath, remote)
+		if out, err := c.runner.CombinedOutput(repoPath, "fetch", remote, defaultBranch); err != nil {
+			return "", fmt.Errorf("fetch failed: %s", strings.TrimSpace(string(out)))
+		}
+		// Start from what was just fetched rather than <remote>/<default>:
+		// a bare clone has no fetch refspec, so the fetch does not update
+		// remote-tracking refs there.
+		out, err := c.runner.Output(repoPath, "rev-parse", "--verify", "-q", "FETCH_HEAD^{commit}")
+		if err != nil {
+			return "", fmt.Errorf("fetched %s %s but found nothing to start from", remote, defaultBranch)
+		}
+		return strings.TrimSpace(string(out)), nil
+	case BaseRef:
+		ref := strings.TrimSpace(base.Ref)
+		if ref == "" {
+			return "", fmt.Errorf("no base ref given")
+		}
+		if _, err := c.runner.Output(repoPath, "rev-parse", "--verify", "-q", ref+"^{commit}"); err != nil {
+			return "", fmt.Errorf("base %q is not a branch, tag or commit", ref)
+		}
+		return ref, nil
+	default:
+		return c.DefaultBranch(repoPath), nil
+	}
+}
+
+func (c *Client) RemoveWorktree(repoPath, wtPath, branch string, deleteBranch bool) error {
+	out, err := c.runner.CombinedOutput(repoPath, "worktree", "remove", wtPath)
 	if err != nil {
+		if strings.Contains(string(out), "cannot remove a locked working tree") {
+			return fmt.Errorf("worktree %q is locked; unlock it first", filepath.Base(wtPath))
+		}
 		return fmt.Errorf("%s", strings.TrimSpace(string(out)))
 	}
 	if deleteBranch && branch != "" && branch != "(detached)" {
-		cmd = exec.Command("git", "branch", "-D", branch)
-		cmd.Dir = repoPath
-		out, err = cmd.CombinedOutput()
+		out, err = c.runner.CombinedOutput(repoPath, "branch", "-D", branch)
 		if err != nil {
 			return fmt.Errorf("worktree removed but branch deletion failed: %s", strings.TrimSpace(string(out)))
 		}
@@ -85,121 +262,135 @@ func RemoveWorktree(repoPath, wtPath, branch string, deleteBranch bool) error {
 	return nil
 }
 
-func ListCommits(repoPath, branch string) ([]Commit, error) {
-	defaultBranch := detectDefaultBranch(repoPath)
+// commitLogFormat emits one header token per commit. The leading \x1e marks
+// the start of a record so it can be told apart from the NUL-terminated
+// numstat entries that -z places after it; \x1f separates fields. The
+// author and committer emails are only read to recognise AI agents; the
+// trailers follow the body, one "Key: value" line each; "only" leaves out
+// the other lines git allows in a trailer block, such as "(cherry picked
+// from commit ...)".
+const commitLogFormat = "--format=%x1e%H%x1f%s%x1f%an%x1f%ae%x1f%ce%x1f%ar%x1f%D%x1f%b%x1f%(trailers:only,unfold)"
+
+func (c *Client) ListCommits(repoPath, branch string) ([]Commit, error) {
+	remotes := c.Remotes(repoPath)
+	defaultBranch := c.detectDefaultBranch(repoPath, remotes.Base)
 	revRange := defaultBranch + ".." + branch
-	// Use %x00 at the end as record separator; %x01 separates fields within.
-	// Format: hash\x01subject\x01author\x01date\x01body\x00
-	cmd := exec.Command("git", "log", revRange,
-		"--format=%H%x01%s%x01%an%x01%ar%x01%b%x00", "--")
-	cmd.Dir = repoPath
-	out, err := cmd.Output()
+	stream, err := c.runner.Stream(repoPath, "log", revRange, "-z", "-M", "--numstat", commitLogFormat, "--")
 	if err != nil {
 		return nil, err
 	}
-	text := strings.TrimSpace(string(out))
-	if text == "" {
-		return nil, nil
+	commits, parseErr := parseCommitLog(stream, c.agentDetector())
+	if err := stream.Close(); err != nil {
+		return nil, err
+	}
+	if parseErr != nil {
+		return nil, parseErr
 	}
-	records := strings.Split(text, "\x00")
+	c.markPushed(repoPath, remotes.Push, defaultBranch, branch, commits)
+	return commits, nil
+}
+
+// parseCommitLog reads the output of `git log -z --numstat` with
+// commitLogFormat token by token, so large ranges never have to be held in
+// memory as a single string.
+func parseCommitLog(r io.Reader, agents *agentDetector) ([]Commit, error) {
+	br := bufio.NewReader(r)
 	var commits []Commit
-	for _, rec := range records {
-		rec = strings.TrimSpace(rec)
-		if rec == "" {
-			continue
-		}
-		fields := strings.SplitN(rec, "\x01", 5)
-		if len(fields) < 4 {
-			continue
+	var current *Commit
+	// Renames are emitted as "adds\tdels\t" followed by two extra
+	// tokens holding the old and new path.
+	renameTokens := 0
+
+	for {
+		tok, err := br.ReadString(0)
+		if err != nil && err != io.EOF {
+			return nil, err
 		}
-		c := Commit{
-			Hash:    fields[0],
-			Subject: fields[1],
-			Author:  fields[2],
-			Date:    fields[3],
+		tok = strings.TrimSuffix(tok, "\x00")
+		switch {
+		case strings.HasPrefix(tok, "\x1e"):
+			commits = append(commits, parseCommitHeader(tok[1:], agents))
+			current = &commits[len(commits)-1]
+			renameTokens = 0
+		case current == nil || tok == "":
+			// Nothing to attribute the token to.
+		case renameTokens > 0:
+			renameTokens--
+			if renameTokens == 0 {
+				current.Files = append(current.Files, tok)
+			}
+		default:
+			tok = strings.TrimPrefix(tok, "\n")
+			fields := strings.SplitN(tok, "\t", 3)
+			if len(fields) < 3 {
+				break
+			}
+			if a, err := strconv.Atoi(fields[0]); err == nil {
+				current.Additions += a
+			}
+			if d, err := strconv.Atoi(fields[1]); err == nil {
+				current.Deletions += d
+			}
+			if fields[2] == "" {
+				renameTokens = 2
+			} else {
+				current.Files = append(current.Files, fields[2])
+			}
 		}
-		if len(fields) == 5 {
-			c.Body = strings.TrimSpace(fields[4])
+		if err == io.EOF {
+			return commits, nil
 		}
-		commits = append(commits, c)
 	}
-	enrichCommits(repoPath, branch, commits)
-	return commits, nil
 }
 
-func enrichCommits(repoPath, branch string, commits []Commit) {
-	// Determine which commits are pushed by finding the remote tip.
-	remoteTip := ""
-	cmd := exec.Command("git", "rev-parse", "--verify", "origin/"+branch)
-	cmd.Dir = repoPath
-	if out, err := cmd.Output(); err == nil {
-		remoteTip = strings.TrimSpace(string(out))
+func parseCommitHeader(header string, agents *agentDetector) Commit {
+	fields := strings.SplitN(header, "\x1f", 9)
+	for len(fields) < 9 {
+		fields = append(fields, "")
+	}
+	commit := Commit{
+		Hash:    fields[0],
+		Subject: fields[1],
+		Author:  fields[2],
+		Date:    fields[5],
+		Body:    strings.TrimSpace(fields[7]),
+	}
+	for _, ref := range strings.Split(fields[6], ", ") {
+		if tag, ok := strings.CutPrefix(ref, "tag: "); ok {
+			commit.Tags = append(commit.Tags, tag)
+		}
+	}
+	// Both subject and body are checked — some commits have the trailer
+	// squashed into the subject line.
+	commit.AIAgents = agents.detect(commit.Subject+"\n"+commit.Body, fields[3], fields[4])
+	commit.Trailers = parseTrailers(fields[8])
+	for _, v := range commit.Trailers.Get("Co-authored-by") {
+		if agents.valueAgent(v) == "" {
+			commit.CoAuthors = append(commit.CoAuthors, v)
+		}
 	}
+	commit.Issues = issueRefs(commit)
+	return commit
+}
 
-	// If we have a remote tip, all commits that are ancestors of it are pushed.
+// markPushed flags the commits that are also reachable from the branch's
+// remote-tracking ref.
+func (c *Client) markPushed(repoPath, remote, defaultBranch, branch string, commits []Commit) {
+	if _, err := c.runner.Output(repoPath, "rev-parse", "--verify", remote+"/"+branch); err != nil {
+		return
+	}
+	out, err := c.runner.Output(repoPath, "log", defaultBranch+".."+remote+"/"+branch, "--format=%H")
+	if err != nil {
+		return
+	}
 	pushedSet := make(map[string]bool)
-	if remoteTip != "" {
-		// Get the set of commits in default..branch that are also in default..origin/branch
-		defaultBranch := detectDefaultBranch(repoPath)
-		cmd := exec.Command("git", "log", defaultBranch+"..origin/"+branch, "--format=%H")
-		cmd.Dir = repoPath
-		if out, err := cmd.Output(); err == nil {
-			for _, h := range strings.Split(strings.TrimSpace(string(out)), "\n") {
-				if h != "" {
-					pushedSet[h] = true
-				}
-			}
+	for _, h := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if h != "" {
+			pushedSet[h] = true
 		}
 	}
-
 	for i := range commits {
 		commits[i].Pushed = pushedSet[commits[i].Hash]
-
-		// Tags
-		cmd := exec.Command("git", "tag", "--points-at", commits[i].Hash)
-		cmd.Dir = repoPath
-		if out, err := cmd.Output(); err == nil {
-			for _, t := range strings.Split(strings.TrimSpace(string(out)), "\n") {
-				if t != "" {
-					commits[i].Tags = append(commits[i].Tags, t)
-				}
-			}
-		}
-
-		// Diff stat and file list
-		cmd = exec.Command("git", "diff-tree", "--no-commit-id", "--numstat", "-r", commits[i].Hash)
-		cmd.Dir = repoPath
-		if out, err := cmd.Output(); err == nil {
-			for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
-				if line == "" {
-					continue
-				}
-				fields := strings.Fields(line)
-				if len(fields) < 3 {
-					continue
-				}
-				if a, err := strconv.Atoi(fields[0]); err == nil {
-					commits[i].Additions += a
-				}
-				if d, err := strconv.Atoi(fields[1]); err == nil {
-					commits[i].Deletions += d
-				}
-				commits[i].Files = append(commits[i].Files, fields[2])
-			}
-		}
-
-		// AI co-authors (check both subject and body — some commits
-		// have the trailer squashed into the subject line)
-		full := commits[i].Subject + "\n" + commits[i].Body
-		matches := aiAgentPattern.FindAllStringSubmatch(full, -1)
-		seen := make(map[string]bool)
-		for _, m := range matches {
-			name := m[1]
-			if !seen[name] {
-				seen[name] = true
-				commits[i].AIAgents = append(commits[i].AIAgents, name)
-			}
-		}
 	}
 }
 
@@ -223,6 +414,10 @@ func parseWorktreeError(output, name, branch string) error {
 		return fmt.Errorf("a worktree named %q already exists", name)
 	case strings.Contains(fatal, "not a valid branch name"):
 		return fmt.Errorf("%q is not a valid branch name", branch)
+	case strings.Contains(fatal, "is already checked out at") || strings.Contains(fatal, "is already used by worktree at"):
+		return fmt.Errorf("branch %q is already checked out in another worktree", branch)
+	case strings.Contains(fatal, "invalid reference"):
+		return fmt.Errorf("branch %q does not exist", branch)
 	case strings.Contains(fatal, "is a missing but locked"):
 		return fmt.Errorf("worktree %q is locked; unlock it first", name)
 	default:
@@ -230,51 +425,60 @@ func parseWorktreeError(output, name, branch string) error {
 	}
 }
 
-func detectDefaultBranch(repoPath string) string {
-	cmd := exec.Command("git", "symbolic-ref", "refs/remotes/origin/HEAD")
-	cmd.Dir = repoPath
-	out, err := cmd.Output()
+// detectDefaultBranch reads the default branch from the base remote's HEAD,
+// falling back to a local main or master.
+func (c *Client) detectDefaultBranch(repoPath, remote string) string {
+	out, err := c.runner.Output(repoPath, "symbolic-ref", "refs/remotes/"+remote+"/HEAD")
 	if err == nil {
 		ref := strings.TrimSpace(string(out))
-		return strings.TrimPrefix(ref, "refs/remotes/origin/")
+		return strings.TrimPrefix(ref, "refs/remotes/"+remote+"/")
 	}
 	for _, name := range []string{"main", "master"} {
-		check := exec.Command("git", "rev-parse", "--verify", "refs/heads/"+name)
-		check.Dir = repoPath
-		if err := check.Run(); err == nil {
+		if _, err := c.runner.Output(repoPath, "rev-parse", "--verify", "refs/heads/"+name); err == nil {
 			return name
 		}
 	}
 	return "main"
 }
 
-// RebaseOnto fetches the latest default branch from origin and rebases the
-// worktree's branch onto it. If the rebase encounters conflicts it is
-// automatically aborted and an error is returned.
-func RebaseOnto(repoPath, wtPath string) error {
-	defaultBranch := detectDefaultBranch(repoPath)
+// defaultRef prefers <remote>/<default> so that ahead/behind reflects what
+// `u` would rebase onto, falling back to the local branch.
+func (c *Client) defaultRef(repoPath, remote, defaultBranch string) string {
+	if _, err := c.runner.Output(repoPath, "rev-parse", "--verify", "-q", "refs/remotes/"+remote+"/"+defaultBranch); err == nil {
+		return remote + "/" + defaultBranch
+	}
+	return defaultBranch
+}
 
-	fetch := exec.Command("git", "fetch", "origin", defaultBranch)
-	fetch.Dir = wtPath
-	if out, err := fetch.CombinedOutput(); err != nil {
-		return fmt.Errorf("fetch failed: %s", strings.TrimSpace(string(out)))
+// defaultTip is defaultRef along with the commit it points at, which is
+// empty when neither ref exists.
+func (c *Client) defaultTip(repoPath, remote, defaultBranch string) (ref, tip string) {
+	if out, err := c.runner.Output(repoPath, "rev-parse", "--verify", "-q", "refs/remotes/"+remote+"/"+defaultBranch); err == nil {
+		return remote + "/" + defaultBranch, strings.TrimSpace(string(out))
 	}
+	if out, err := c.runner.Output(repoPath, "rev-parse", "--verify", "-q", "refs/heads/"+defaultBranch); err == nil {
+		return defaultBranch, strings.TrimSpace(string(out))
+	}
+	return defaultBranch, ""
+}
 
-	rebase := exec.Command("git", "rebase", "origin/"+defaultBranch)
-	rebase.Dir = wtPath
-	if out, err := rebase.CombinedOutput(); err != nil {
-		abort := exec.Command("git", "rebase", "--abort")
-		abort.Dir = wtPath
-		abort.Run()
-		return fmt.Errorf("conflicts detected — resolve in terminal (%s)", strings.TrimSpace(string(out)))
+// aheadBehind counts the commits head has that base lacks and vice versa.
+func (c *Client) aheadBehind(repoPath, base, head string) (ahead, behind int) {
+	out, err := c.runner.Output(repoPath, "rev-list", "--left-right", "--count", base+"..."+head)
+	if err != nil {
+		return 0, 0
 	}
-	return nil
+	fields := strings.Fields(string(out))
+	if len(fields) != 2 {
+		return 0, 0
+	}
+	behind, _ = strconv.Atoi(fields[0])
+	ahead, _ = strconv.Atoi(fields[1])
+	return ahead, behind
 }
 
-func diffStats(repoPath, base, head string) (additions, deletions int) {
-	cmd := exec.Command("git", "diff", "--numstat", base+"..."+head)
-	cmd.Dir = repoPath
-	out, err := cmd.Output()
+func (c *Client) diffStats(repoPath, base, head string) (additions, deletions int) {
+	out, err := c.runner.Output(repoPath, "diff", "--numstat", base+"..."+head)
 	if err != nil {
 		return 0, 0
 	}
@@ -296,6 +500,18 @@ func diffStats(repoPath, base, head string) (additions, deletions int) {
 	return additions, deletions
 }
 
+// unquoteReason cleans up the reason after a "locked" or "prunable"
+// attribute. git C-quotes reasons that contain newlines.
+func unquoteReason(s string) string {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, `"`) {
+		if u, err := strconv.Unquote(s); err == nil {
+			return u
+		}
+	}
+	return s
+}
+
 func parseWorktrees(output string) []Worktree {
 	var worktrees []Worktree
 	var current Worktree
@@ -323,6 +539,12 @@ func parseWorktrees(output string) []Worktree {
 			current.Bare = true
 		} else if line == "detached" {
 			current.Branch = "(detached)"
+		} else if line == "locked" || strings.HasPrefix(line, "locked ") {
+			current.Locked = true
+			current.LockReason = unquoteReason(strings.TrimPrefix(line, "locked"))
+		} else if line == "prunable" || strings.HasPrefix(line, "prunable ") {
+			current.Prunable = true
+			current.PruneReason = unquoteReason(strings.TrimPrefix(line, "prunable"))
 		}
 	}
 
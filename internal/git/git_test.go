@@ -0,0 +1,468 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/marcellolins/mossy/internal/git/gittest"
+)
+
+const porcelain = `worktree /src/app
+HEAD 1111111111111111111111111111111111111111
+branch refs/heads/main
+
+worktree /src/feature-a
+HEAD 2222222222222222222222222222222222222222
+branch refs/heads/feature/a
+
+worktree /src/scratch
+HEAD 3333333333333333333333333333333333333333
+detached
+
+`
+
+func TestParseWorktrees(t *testing.T) {
+	got := parseWorktrees(porcelain)
+	want := []Worktree{
+		{Path: "/src/app", HEAD: "1111111111111111111111111111111111111111", Branch: "main"},
+		{Path: "/src/feature-a", HEAD: "2222222222222222222222222222222222222222", Branch: "feature/a"},
+		{Path: "/src/scratch", HEAD: "3333333333333333333333333333333333333333", Branch: "(detached)"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseWorktrees:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestParseWorktreesBareWithoutTrailingBlank(t *testing.T) {
+	got := parseWorktrees("worktree /src/app.git\nbare")
+	want := []Worktree{{Path: "/src/app.git", Bare: true}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestListWorktrees(t *testing.T) {
+	r := originRunner(t)
+	r.On("worktree", "list", "--porcelain").Return(porcelain)
+	r.On("symbolic-ref", "refs/remotes/origin/HEAD").Return("refs/remotes/origin/main\n")
+	r.On("diff", "--numstat", "main...2222222222222222222222222222222222222222").
+		Return("10\t2\tREADME.md\n-\t-\tlogo.png\n3\t0\tmain.go\n")
+	r.On("log", "main..2222222222222222222222222222222222222222", "-z", "-M", "--numstat", commitLogFormat, "--").
+		Return("\x1e2222222222222222222222222222222222222222\x1fAdd docs\x1fAda\x1fada@example.com\x1fada@example.com\x1f1 hour ago\x1f\x1fCo-authored-by: Copilot <c@github.com>\n\x00" +
+			"\n10\t2\tREADME.md\x00")
+	r.On("rev-parse", "--verify", "-q", "refs/remotes/origin/main").Return("1111111111111111111111111111111111111111\n")
+	r.On("rev-list", "--left-right", "--count", "origin/main...2222222222222222222222222222222222222222").Return("12\t3\n")
+	r.On("rev-list", "--left-right", "--count", "origin/main...3333333333333333333333333333333333333333").Return("0\t1\n")
+	r.On("status", "--porcelain=v2", "--branch", "-z").
+		Return("# branch.oid 2222222222222222222222222222222222222222\x00# branch.head feature/a\x00" +
+			"# branch.upstream origin/feature/a\x00# branch.ab +2 -1\x00? notes.txt\x00")
+	r.On("rev-parse", "--absolute-git-dir").Return(t.TempDir() + "\n")
+
+	wts, err := New(r).ListWorktrees("/src/app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(wts) != 3 {
+		t.Fatalf("got %d worktrees, want the main one and two linked", len(wts))
+	}
+	if !wts[0].Main || wts[1].Main || wts[2].Main {
+		t.Errorf("only the first worktree should be marked main: %v %v %v", wts[0].Main, wts[1].Main, wts[2].Main)
+	}
+	if wts[0].Additions != 0 || wts[0].DefaultAhead != 0 {
+		t.Errorf("main worktree on the default branch should have no stats: %+v", wts[0])
+	}
+	wts = wts[1:]
+	if wts[0].AI.Agents["Copilot"].Commits != 1 || wts[0].AI.Total.Commits != 1 {
+		t.Errorf("AI stats = %+v", wts[0].AI)
+	}
+	if wts[0].Additions != 13 || wts[0].Deletions != 2 {
+		t.Errorf("feature-a stats = +%d -%d, want +13 -2", wts[0].Additions, wts[0].Deletions)
+	}
+	if wts[1].Additions != 0 || wts[1].Deletions != 0 {
+		t.Errorf("detached worktree should have no stats, got +%d -%d", wts[1].Additions, wts[1].Deletions)
+	}
+	if wts[0].Status.Untracked != 1 {
+		t.Errorf("feature-a status = %+v, want one untracked file", wts[0].Status)
+	}
+	if wts[0].DefaultAhead != 3 || wts[0].DefaultBehind != 12 {
+		t.Errorf("feature-a vs default = ↑%d ↓%d, want ↑3 ↓12", wts[0].DefaultAhead, wts[0].DefaultBehind)
+	}
+	if st := wts[0].Status; st.Upstream != "origin/feature/a" || st.Ahead != 2 || st.Behind != 1 {
+		t.Errorf("feature-a vs upstream = %s ⇡%d ⇣%d", st.Upstream, st.Ahead, st.Behind)
+	}
+	if wts[1].DefaultAhead != 1 {
+		t.Errorf("detached worktree ahead = %d, want 1", wts[1].DefaultAhead)
+	}
+}
+
+func TestListWorktreesCachesAIStats(t *testing.T) {
+	const feat = "2222222222222222222222222222222222222222"
+	list := func(mainHEAD string) string {
+		return "worktree /src/app\nHEAD " + mainHEAD + "\nbranch refs/heads/main\n\n" +
+			"worktree /src/feat\nHEAD " + feat + "\nbranch refs/heads/feat\n\n"
+	}
+	r := originRunner(t)
+	r.On("worktree", "list", "--porcelain").Return(list("1111111111111111111111111111111111111111")).
+		On("worktree", "list", "--porcelain").Return(list("1111111111111111111111111111111111111111")).
+		On("worktree", "list", "--porcelain").Return(list("4444444444444444444444444444444444444444"))
+	r.On("symbolic-ref", "refs/remotes/origin/HEAD").Return("refs/remotes/origin/main\n")
+	r.On("rev-parse", "--verify", "-q", "refs/remotes/origin/main").Fail("")
+	r.On("diff", "--numstat", "main..."+feat).Return("1\t0\tmain.go\n")
+	r.On("log", "main.."+feat, "-z", "-M", "--numstat", commitLogFormat, "--").
+		Return("\x1e" + feat + "\x1fFix\x1fAda\x1fada@example.com\x1fada@example.com\x1f1 hour ago\x1f\x1f\x1f\n\x00\n1\t0\tmain.go\x00")
+	r.On("rev-list", "--left-right", "--count", "main..."+feat).Return("0\t1\n")
+	r.On("status", "--porcelain=v2", "--branch", "-z").Return("# branch.head feat\x00")
+	r.On("rev-parse", "--absolute-git-dir").Return(t.TempDir() + "\n")
+	c := New(r)
+	countLog := func() int {
+		n := 0
+		for _, call := range r.Calls() {
+			if call.Args[0] == "log" {
+				n++
+			}
+		}
+		return n
+	}
+
+	for range 2 {
+		wts, err := c.ListWorktrees("/src/app")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if wts[1].AI.Total.Commits != 1 {
+			t.Fatalf("AI stats = %+v, want one commit", wts[1].AI)
+		}
+	}
+	if n := countLog(); n != 1 {
+		t.Fatalf("log read %d times, want once while nothing moved", n)
+	}
+
+	// The default branch moved: the branch's own commits may have changed.
+	if _, err := c.ListWorktrees("/src/app"); err != nil {
+		t.Fatal(err)
+	}
+	if n := countLog(); n != 2 {
+		t.Errorf("log read %d times, want again after main moved", n)
+	}
+}
+
+func TestDetectDefaultBranchFallback(t *testing.T) {
+	r := gittest.New(t)
+	r.On("symbolic-ref", "refs/remotes/origin/HEAD").Fail("fatal: ref refs/remotes/origin/HEAD is not a symbolic ref")
+	r.On("rev-parse", "--verify", "refs/heads/main").Fail("fatal: Needed a single revision")
+	r.On("rev-parse", "--verify", "refs/heads/master").Return("abc\n")
+
+	if got := New(r).detectDefaultBranch("/src/app", "origin"); got != "master" {
+		t.Fatalf("detectDefaultBranch = %q, want master", got)
+	}
+}
+
+func TestParseWorktreeError(t *testing.T) {
+	tests := []struct {
+		name   string
+		branch string
+		output string
+		want   string
+	}{
+		{
+			name:   "branch exists",
+			output: "Preparing worktree (new branch 'feat')\nfatal: a branch named 'feat' already exists\n",
+			want:   `a branch named "feat" already exists`,
+		},
+		{
+			name:   "path exists",
+			output: "fatal: '/src/feat' already exists\n",
+			want:   `a worktree named "feat" already exists`,
+		},
+		{
+			name:   "invalid branch",
+			branch: "a..b",
+			output: "fatal: 'a..b' is not a valid branch name\n",
+			want:   `"a..b" is not a valid branch name`,
+		},
+		{
+			name:   "locked",
+			output: "fatal: '/src/feat' is a missing but locked worktree;\n",
+			want:   `worktree "feat" is locked; unlock it first`,
+		},
+		{
+			name:   "preamble keywords are ignored",
+			output: "Preparing worktree (checking out 'already exists')\nfatal: could not create directory of '.git/worktrees/feat'\n",
+			want:   "could not create directory of '.git/worktrees/feat'",
+		},
+		{
+			name:   "branch checked out elsewhere",
+			output: "fatal: 'feat' is already checked out at '/src/other'\n",
+			want:   `branch "feat" is already checked out in another worktree`,
+		},
+		{
+			name:   "unknown branch",
+			output: "fatal: invalid reference: feat\n",
+			want:   `branch "feat" does not exist`,
+		},
+		{
+			name:   "no fatal line",
+			output: "  something odd happened  \n",
+			want:   "something odd happened",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			branch := tt.branch
+			if branch == "" {
+				branch = "feat"
+			}
+			err := parseWorktreeError(tt.output, "feat", branch)
+			if err == nil || err.Error() != tt.want {
+				t.Fatalf("got %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddWorktreeMapsErrors(t *testing.T) {
+	r := originRunner(t)
+	r.On("symbolic-ref", "refs/remotes/origin/HEAD").Return("refs/remotes/origin/main\n")
+	wtPath := filepath.Join(t.TempDir(), "feat")
+	r.On("worktree", "add", "--no-track", "-b", "feat", wtPath, "main").
+		Fail("Preparing worktree (new branch 'feat')\nfatal: a branch named 'feat' already exists\n")
+
+	err := New(r).AddWorktree("/src/app", AddWorktreeOptions{Path: wtPath, Name: "feat", Branch: "feat"})
+	if err == nil || err.Error() != `a branch named "feat" already exists` {
+		t.Fatalf("AddWorktree error = %v", err)
+	}
+}
+
+func TestAddWorktreeModes(t *testing.T) {
+	tests := []struct {
+		name string
+		opts AddWorktreeOptions
+		args []string
+	}{
+		{
+			name: "new branch from default",
+			opts: AddWorktreeOptions{Name: "feat", Branch: "feature/x"},
+			args: []string{"worktree", "add", "--no-track", "-b", "feature/x", "{path}", "main"},
+		},
+		{
+			name: "new branch from fetched default",
+			opts: AddWorktreeOptions{Name: "feat", Branch: "feature/x", Base: Base{Kind: BaseRemoteDefault}},
+			args: []string{"worktree", "add", "--no-track", "-b", "feature/x", "{path}", "4444444444444444444444444444444444444444"},
+		},
+		{
+			name: "new branch from tag",
+			opts: AddWorktreeOptions{Name: "feat", Branch: "feature/x", Base: Base{Kind: BaseRef, Ref: "v1.2.0"}},
+			args: []string{"worktree", "add", "--no-track", "-b", "feature/x", "{path}", "v1.2.0"},
+		},
+		{
+			name: "existing local branch",
+			opts: AddWorktreeOptions{Name: "feat", Branch: "feature/x", Mode: AddExistingBranch},
+			args: []string{"worktree", "add", "{path}", "feature/x"},
+		},
+		{
+			name: "remote branch",
+			opts: AddWorktreeOptions{Name: "feat", Branch: "origin/feature/x", Mode: AddRemoteBranch},
+			args: []string{"worktree", "add", "--track", "-b", "feature/x", "{path}", "origin/feature/x"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := originRunner(t)
+			r.On("symbolic-ref", "refs/remotes/origin/HEAD").Return("refs/remotes/origin/main\n")
+			r.On("fetch", "origin", "main").Return("")
+			r.On("rev-parse", "--verify", "-q", "FETCH_HEAD^{commit}").Return("4444444444444444444444444444444444444444\n")
+			r.On("rev-parse", "--verify", "-q", "v1.2.0^{commit}").Return("abc\n")
+			// Nested so that AddWorktree has to create the parent.
+			wtPath := filepath.Join(t.TempDir(), "app.worktrees", "feat")
+			args := append([]string(nil), tt.args...)
+			for i := range args {
+				if args[i] == "{path}" {
+					args[i] = wtPath
+				}
+			}
+			r.On(args...).Return("")
+			tt.opts.Path = wtPath
+			if err := New(r).AddWorktree("/src/app", tt.opts); err != nil {
+				t.Fatal(err)
+			}
+			if fetched := r.Called("fetch", "origin", "main"); fetched != (tt.opts.Base.Kind == BaseRemoteDefault) {
+				t.Errorf("fetch called = %v", fetched)
+			}
+			if _, err := os.Stat(filepath.Dir(wtPath)); err != nil {
+				t.Errorf("parent directory not created: %v", err)
+			}
+		})
+	}
+}
+
+func TestAddWorktreeUnknownBase(t *testing.T) {
+	r := gittest.New(t)
+	r.On("rev-parse", "--verify", "-q", "nope^{commit}").Fail("")
+
+	err := New(r).AddWorktree("/src/app", AddWorktreeOptions{
+		Path: filepath.Join(t.TempDir(), "feat"), Name: "feat", Branch: "feat", Base: Base{Kind: BaseRef, Ref: "nope"},
+	})
+	if err == nil || err.Error() != `base "nope" is not a branch, tag or commit` {
+		t.Fatalf("AddWorktree error = %v", err)
+	}
+	for _, c := range r.Calls() {
+		if c.Args[0] == "worktree" {
+			t.Fatal("worktree add should not run with an unknown base")
+		}
+	}
+}
+
+func TestParseBranches(t *testing.T) {
+	out := "refs/heads/feat\x00feat\x00origin/feat\x00\x00\n" +
+		"refs/heads/main\x00main\x00origin/main\x00/src/app\x00\n" +
+		"refs/remotes/origin/HEAD\x00origin\x00\x00\x00refs/remotes/origin/main\n" +
+		"refs/remotes/origin/team/x\x00origin/team/x\x00\x00\x00\n"
+
+	got := parseBranches(out)
+	want := []Branch{
+		{Name: "feat", Upstream: "origin/feat"},
+		{Name: "main", Upstream: "origin/main", WorktreePath: "/src/app"},
+		{Name: "origin/team/x", Remote: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseBranches:\n got %+v\nwant %+v", got, want)
+	}
+	if got[2].LocalName() != "team/x" {
+		t.Errorf("LocalName = %q", got[2].LocalName())
+	}
+}
+
+func TestUpdateWorktreeAbortsOnConflict(t *testing.T) {
+	r := originRunner(t)
+	r.On("symbolic-ref", "refs/remotes/origin/HEAD").Return("refs/remotes/origin/main\n")
+	r.On("fetch", "origin", "main").Return("")
+	r.On("rebase", "origin/main").Fail("CONFLICT (content): Merge conflict in main.go\n")
+	r.On("diff", "--name-only", "--diff-filter=U", "-z").Return("main.go\x00")
+	r.On("rebase", "--abort").Return("")
+
+	err := New(r).UpdateWorktree("/src/app", "/src/feat", UpdateOptions{})
+	if err == nil || !strings.Contains(err.Error(), "Merge conflict in main.go") {
+		t.Fatalf("UpdateWorktree error = %v", err)
+	}
+	if !r.Called("rebase", "--abort") {
+		t.Fatal("expected rebase to be aborted")
+	}
+	for _, c := range r.Calls() {
+		if c.Dir == "/src/app" && (c.Args[0] == "symbolic-ref" || c.Args[0] == "remote" || c.Args[0] == "config") {
+			continue
+		}
+		if c.Dir != "/src/feat" {
+			t.Errorf("git %s ran in %s, want worktree dir", c, c.Dir)
+		}
+	}
+}
+
+func TestUpdateWorktreeFetchFailureSkipsRebase(t *testing.T) {
+	r := originRunner(t)
+	r.On("symbolic-ref", "refs/remotes/origin/HEAD").Return("refs/remotes/origin/main\n")
+	r.On("fetch", "origin", "main").Fail("fatal: unable to access remote\n")
+
+	err := New(r).UpdateWorktree("/src/app", "/src/feat", UpdateOptions{})
+	if err == nil || err.Error() != "fetch failed: fatal: unable to access remote" {
+		t.Fatalf("UpdateWorktree error = %v", err)
+	}
+	if r.Called("rebase", "origin/main") || r.Called("rebase", "--abort") {
+		t.Fatal("rebase should not run when fetch fails")
+	}
+}
+
+func TestUpdateWorktreeRebase(t *testing.T) {
+	r := originRunner(t)
+	r.On("symbolic-ref", "refs/remotes/origin/HEAD").Return("refs/remotes/origin/main\n")
+	r.On("fetch", "origin", "main").Return("")
+	r.On("rebase", "origin/main").Return("Successfully rebased and updated refs/heads/feat.\n")
+
+	if err := New(r).UpdateWorktree("/src/app", "/src/feat", UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if r.Called("rebase", "--abort") {
+		t.Fatal("rebase should not be aborted on success")
+	}
+}
+
+func TestListCommits(t *testing.T) {
+	r := originRunner(t)
+	r.On("symbolic-ref", "refs/remotes/origin/HEAD").Return("refs/remotes/origin/main\n")
+	r.On("log", "main..feat", "-z", "-M", "--numstat", commitLogFormat, "--").
+		Return("\x1eaaa\x1fAdd parser\x1fAda\x1fada@example.com\x1fada@example.com\x1f2 hours ago\x1fHEAD -> feat\x1fDetails\n\nCo-authored-by: Claude <noreply@anthropic.com>\n\x00" +
+			"\n4\t1\tparser.go\x00" +
+			"\x1ebbb\x1fInitial\x1fBob\x1fbob@example.com\x1fbob@example.com\x1f3 days ago\x1ftag: v0.1.0, origin/feat\x1f\x00" +
+			"\n1\t0\tgo.mod\x002\t0\tmain.go\x00")
+	r.On("rev-parse", "--verify", "origin/feat").Return("bbb\n")
+	r.On("log", "main..origin/feat", "--format=%H").Return("bbb\n")
+
+	commits, err := New(r).ListCommits("/src/app", "feat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 2 {
+		t.Fatalf("got %d commits, want 2", len(commits))
+	}
+	a, b := commits[0], commits[1]
+	if a.Subject != "Add parser" || a.Author != "Ada" || a.Pushed || len(a.Tags) != 0 {
+		t.Errorf("unexpected first commit: %+v", a)
+	}
+	if !reflect.DeepEqual(a.AIAgents, []string{"Claude"}) {
+		t.Errorf("AIAgents = %v", a.AIAgents)
+	}
+	if !b.Pushed || !reflect.DeepEqual(b.Tags, []string{"v0.1.0"}) {
+		t.Errorf("unexpected second commit: %+v", b)
+	}
+	if b.Additions != 3 || !reflect.DeepEqual(b.Files, []string{"go.mod", "main.go"}) {
+		t.Errorf("second commit stats: +%d files %v", b.Additions, b.Files)
+	}
+	if n := len(r.Calls()); n != 6 {
+		t.Errorf("ListCommits made %d git calls, want 6 regardless of commit count", n)
+	}
+}
+
+func TestParseCommitLogRenamesAndSpaces(t *testing.T) {
+	// Captured from `git log -z -M --numstat` on a throwaway repository.
+	out := "\x1eccc\x1fempty\x1fA\x1fa@example.com\x1fa@example.com\x1f0 seconds ago\x1fHEAD -> feat\x1f\x00" +
+		"\x1ebbb\x1frename\x1fA\x1fa@example.com\x1fa@example.com\x1f0 seconds ago\x1ftag: v1\x1f\x00" +
+		"\n-\t-\tbin\x001\t0\t\x00b\x00c\x00" +
+		"\x1eaaa\x1ftwo files\x1fA\x1fa@example.com\x1fa@example.com\x1f0 seconds ago\x1f\x1fbody line\n\x00" +
+		"\n1\t0\tb\x001\t0\tfile with space.txt\x00"
+
+	commits, err := parseCommitLog(strings.NewReader(out), defaultAgentDetector)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 3 {
+		t.Fatalf("got %d commits, want 3", len(commits))
+	}
+	if len(commits[0].Files) != 0 {
+		t.Errorf("empty commit has files %v", commits[0].Files)
+	}
+	if got := commits[1].Files; !reflect.DeepEqual(got, []string{"bin", "c"}) {
+		t.Errorf("rename commit files = %v", got)
+	}
+	if commits[1].Additions != 1 || commits[1].Deletions != 0 {
+		t.Errorf("binary files should not count: +%d -%d", commits[1].Additions, commits[1].Deletions)
+	}
+	if got := commits[2].Files; !reflect.DeepEqual(got, []string{"b", "file with space.txt"}) {
+		t.Errorf("files = %v", got)
+	}
+	if commits[2].Body != "body line" {
+		t.Errorf("body = %q", commits[2].Body)
+	}
+}
+
+func TestListCommitsStreamFailure(t *testing.T) {
+	r := originRunner(t)
+	r.On("symbolic-ref", "refs/remotes/origin/HEAD").Return("refs/remotes/origin/main\n")
+	r.On("log", "main..gone", "-z", "-M", "--numstat", commitLogFormat, "--").
+		Fail("fatal: bad revision 'main..gone'\n")
+
+	if _, err := New(r).ListCommits("/src/app", "gone"); err == nil {
+		t.Fatal("expected an error for a failing git log")
+	}
+}
@@ -0,0 +1,151 @@
+// Package gittest provides a scripted git.Runner for tests.
+package gittest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// ErrExit is returned by stubs registered with Fail, standing in for the
+// *exec.ExitError a real git process would produce.
+var ErrExit = errors.New("exit status 1")
+
+// Call records a single invocation made through the Runner. Env is only
+// set for StreamCombined.
+type Call struct {
+	Dir  string
+	Args []string
+	Env  []string
+}
+
+// String renders the call the way it would be typed after "git".
+func (c Call) String() string {
+	return strings.Join(c.Args, " ")
+}
+
+type response struct {
+	out string
+	err error
+}
+
+// Runner is a fake git.Runner. Responses are keyed by the space-joined
+// argument list; several responses for the same key are returned in order
+// and the last one repeats. A call without a registered response fails
+// and is reported as a test error, so every command a test relies on —
+// including the ones expected to fail — has to be scripted.
+type Runner struct {
+	t         testing.TB
+	mu        sync.Mutex
+	responses map[string][]response
+	calls     []Call
+}
+
+func New(t testing.TB) *Runner {
+	return &Runner{t: t, responses: make(map[string][]response)}
+}
+
+// Stub is returned by On to attach a response to an argument list.
+type Stub struct {
+	r   *Runner
+	key string
+}
+
+// On starts scripting a response for "git <args...>".
+func (r *Runner) On(args ...string) *Stub {
+	return &Stub{r: r, key: strings.Join(args, " ")}
+}
+
+// Return makes the call succeed with the given output.
+func (s *Stub) Return(out string) *Runner {
+	return s.respond(out, nil)
+}
+
+// Fail makes the call exit non-zero with the given output.
+func (s *Stub) Fail(out string) *Runner {
+	return s.respond(out, ErrExit)
+}
+
+func (s *Stub) respond(out string, err error) *Runner {
+	s.r.mu.Lock()
+	defer s.r.mu.Unlock()
+	s.r.responses[s.key] = append(s.r.responses[s.key], response{out: out, err: err})
+	return s.r
+}
+
+// Calls returns every invocation made so far, in order.
+func (r *Runner) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// Called reports whether "git <args...>" was invoked.
+func (r *Runner) Called(args ...string) bool {
+	key := strings.Join(args, " ")
+	for _, c := range r.Calls() {
+		if c.String() == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Runner) Output(dir string, args ...string) ([]byte, error) {
+	return r.run(dir, nil, args)
+}
+
+func (r *Runner) CombinedOutput(dir string, args ...string) ([]byte, error) {
+	return r.run(dir, nil, args)
+}
+
+// Stream returns the scripted output as a reader. A scripted failure is
+// reported when the reader is closed, mirroring a process exit status.
+func (r *Runner) Stream(dir string, args ...string) (io.ReadCloser, error) {
+	return r.stream(dir, nil, args)
+}
+
+// StreamCombined behaves like Stream; the fake has no separate stderr and
+// never times out.
+func (r *Runner) StreamCombined(_ context.Context, dir string, env []string, args ...string) (io.ReadCloser, error) {
+	return r.stream(dir, env, args)
+}
+
+func (r *Runner) stream(dir string, env, args []string) (io.ReadCloser, error) {
+	out, err := r.run(dir, env, args)
+	if out == nil && err != nil {
+		return nil, err
+	}
+	return &stream{Reader: strings.NewReader(string(out)), err: err}, nil
+}
+
+type stream struct {
+	io.Reader
+	err error
+}
+
+func (s *stream) Close() error {
+	return s.err
+}
+
+func (r *Runner) run(dir string, env, args []string) ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	call := Call{Dir: dir, Args: append([]string(nil), args...), Env: env}
+	r.calls = append(r.calls, call)
+	queue := r.responses[call.String()]
+	if len(queue) == 0 {
+		r.t.Helper()
+		r.t.Errorf("gittest: unexpected call in %s: git %s", dir, call)
+		return nil, fmt.Errorf("gittest: unexpected call: git %s", call)
+	}
+	resp := queue[0]
+	if len(queue) > 1 {
+		r.responses[call.String()] = queue[1:]
+	}
+	return []byte(resp.out), resp.err
+}
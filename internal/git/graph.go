@@ -0,0 +1,89 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GraphRow is one line of a commit graph. Rows that only continue the
+// lanes between commits have an empty Hash.
+type GraphRow struct {
+	// Graph is the lane drawing in front of the commit, in box-drawing
+	// characters.
+	Graph   string
+	Hash    string
+	Subject string
+	Author  string
+	Date    string
+	// Refs is git's decoration of the commit, e.g. "HEAD -> main, tag: v1".
+	Refs string
+	// Worktrees are the paths of the worktrees whose HEAD is this commit.
+	Worktrees []string
+}
+
+const graphLogFormat = "--format=%x1f%H%x1f%s%x1f%an%x1f%ar%x1f%D"
+
+// graphGlyphs turns the ASCII art of `git log --graph` into box-drawing
+// characters.
+var graphGlyphs = strings.NewReplacer(
+	"*", "●",
+	"|", "│",
+	"/", "╱",
+	"\\", "╲",
+	"_", "─",
+	"-", "─",
+	".", "─",
+)
+
+// CommitGraph returns the commit DAG of the default branch and the HEADs
+// of all worktrees, newest first and at most limit commits, with every
+// worktree attached to its tip commit.
+func (c *Client) CommitGraph(repoPath string, limit int) ([]GraphRow, error) {
+	out, err := c.runner.Output(repoPath, "worktree", "list", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	base := c.Remotes(repoPath).Base
+	revs := []string{c.defaultRef(repoPath, base, c.detectDefaultBranch(repoPath, base))}
+	heads := make(map[string][]string)
+	for _, wt := range parseWorktrees(string(out)) {
+		if wt.Bare || wt.Prunable || wt.HEAD == "" {
+			continue
+		}
+		if len(heads[wt.HEAD]) == 0 {
+			revs = append(revs, wt.HEAD)
+		}
+		heads[wt.HEAD] = append(heads[wt.HEAD], wt.Path)
+	}
+
+	args := []string{"log", "--graph", "--topo-order", "--no-color", fmt.Sprintf("--max-count=%d", limit), graphLogFormat}
+	args = append(args, revs...)
+	out, err = c.runner.Output(repoPath, append(args, "--")...)
+	if err != nil {
+		return nil, err
+	}
+	return parseGraph(string(out), heads), nil
+}
+
+// parseGraph splits `git log --graph` output into rows. Each commit line
+// is the graph followed by graphLogFormat's \x1f-separated fields.
+func parseGraph(output string, heads map[string][]string) []GraphRow {
+	var rows []GraphRow
+	for _, line := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		graph, rest, isCommit := strings.Cut(line, "\x1f")
+		row := GraphRow{Graph: graphGlyphs.Replace(strings.TrimRight(graph, " "))}
+		if isCommit {
+			fields := strings.SplitN(rest, "\x1f", 5)
+			for len(fields) < 5 {
+				fields = append(fields, "")
+			}
+			row.Hash, row.Subject, row.Author, row.Date, row.Refs = fields[0], fields[1], fields[2], fields[3], fields[4]
+			row.Worktrees = heads[row.Hash]
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
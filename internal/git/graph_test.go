@@ -0,0 +1,47 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCommitGraph(t *testing.T) {
+	r := originRunner(t)
+	r.On("worktree", "list", "--porcelain").Return(
+		"worktree /src/app\nHEAD 06e5808\nbranch refs/heads/main\n\n" +
+			"worktree /src/a\nHEAD 8ff3dd4\nbranch refs/heads/b\n\n" +
+			"worktree /src/c\nHEAD d95211e\nbranch refs/heads/c\n\n" +
+			"worktree /src/gone\nHEAD 0d4b73a\ndetached\nprunable gitdir file points to non-existent location\n\n")
+	r.On("symbolic-ref", "refs/remotes/origin/HEAD").Return("refs/remotes/origin/main\n")
+	r.On("rev-parse", "--verify", "-q", "refs/remotes/origin/main").Fail("")
+	// Captured from `git log --graph` on a throwaway repository with a
+	// stacked branch and a branch that merged main back in.
+	r.On("log", "--graph", "--topo-order", "--no-color", "--max-count=50", graphLogFormat,
+		"main", "06e5808", "8ff3dd4", "d95211e", "--").Return(
+		"* \x1f8ff3dd4\x1fb 1 stacked\x1fAda\x1f1 hour ago\x1fb\n" +
+			"* \x1f55bd189\x1fa 2\x1fAda\x1f2 hours ago\x1fa\n" +
+			"| *   \x1fd95211e\x1fmerge main\x1fBob\x1f3 hours ago\x1fc\n" +
+			"| |\\  \n" +
+			"| | * \x1f06e5808\x1fmain 4\x1fBob\x1f4 hours ago\x1fHEAD -> main\n" +
+			"| |/  \n" +
+			"|/|   \n" +
+			"* | \x1fadd6ff1\x1fmain 3\x1fAda\x1f1 day ago\x1f\n")
+
+	rows, err := New(r).CommitGraph("/src/app", 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []GraphRow{
+		{Graph: "●", Hash: "8ff3dd4", Subject: "b 1 stacked", Author: "Ada", Date: "1 hour ago", Refs: "b", Worktrees: []string{"/src/a"}},
+		{Graph: "●", Hash: "55bd189", Subject: "a 2", Author: "Ada", Date: "2 hours ago", Refs: "a"},
+		{Graph: "│ ●", Hash: "d95211e", Subject: "merge main", Author: "Bob", Date: "3 hours ago", Refs: "c", Worktrees: []string{"/src/c"}},
+		{Graph: "│ │╲"},
+		{Graph: "│ │ ●", Hash: "06e5808", Subject: "main 4", Author: "Bob", Date: "4 hours ago", Refs: "HEAD -> main", Worktrees: []string{"/src/app"}},
+		{Graph: "│ │╱"},
+		{Graph: "│╱│"},
+		{Graph: "● │", Hash: "add6ff1", Subject: "main 3", Author: "Ada", Date: "1 day ago"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Fatalf("CommitGraph:\n got %+v\nwant %+v", rows, want)
+	}
+}
@@ -0,0 +1,64 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LockWorktree marks a worktree as locked so git will not prune or remove
+// it, e.g. while it lives on a drive that is not always mounted.
+func (c *Client) LockWorktree(repoPath, wtPath, reason string) error {
+	args := []string{"worktree", "lock"}
+	if reason != "" {
+		args = append(args, "--reason", reason)
+	}
+	if out, err := c.runner.CombinedOutput(repoPath, append(args, wtPath)...); err != nil {
+		return fmt.Errorf("%s", fatalLine(out))
+	}
+	return nil
+}
+
+// UnlockWorktree removes the lock from a worktree.
+func (c *Client) UnlockWorktree(repoPath, wtPath string) error {
+	if out, err := c.runner.CombinedOutput(repoPath, "worktree", "unlock", wtPath); err != nil {
+		return fmt.Errorf("%s", fatalLine(out))
+	}
+	return nil
+}
+
+// PruneWorktrees deletes the administrative data of worktrees whose
+// directories no longer exist and returns the names of the pruned entries.
+// Locked worktrees are left alone.
+func (c *Client) PruneWorktrees(repoPath string) ([]string, error) {
+	out, err := c.runner.CombinedOutput(repoPath, "worktree", "prune", "-v")
+	if err != nil {
+		return nil, fmt.Errorf("%s", fatalLine(out))
+	}
+	return parsePruned(string(out)), nil
+}
+
+// parsePruned extracts the entry names from `git worktree prune -v` lines
+// of the form "Removing worktrees/<name>: <reason>".
+func parsePruned(output string) []string {
+	var names []string
+	for _, line := range strings.Split(output, "\n") {
+		rest, ok := strings.CutPrefix(line, "Removing worktrees/")
+		if !ok {
+			continue
+		}
+		name, _, _ := strings.Cut(rest, ":")
+		names = append(names, name)
+	}
+	return names
+}
+
+// fatalLine returns git's "fatal:" message without the prefix, or the last
+// line of output when there is none.
+func fatalLine(out []byte) string {
+	for _, line := range strings.Split(string(out), "\n") {
+		if msg, ok := strings.CutPrefix(strings.TrimSpace(line), "fatal: "); ok {
+			return msg
+		}
+	}
+	return lastLine(out)
+}
@@ -0,0 +1,81 @@
+package git
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/marcellolins/mossy/internal/git/gittest"
+)
+
+func TestParseWorktreesLockedAndPrunable(t *testing.T) {
+	out := "worktree /src/app\nHEAD 1111111111111111111111111111111111111111\nbranch refs/heads/main\n\n" +
+		"worktree /src/usb\nHEAD 2222222222222222222222222222222222222222\nbranch refs/heads/usb\nlocked on usb drive\n\n" +
+		"worktree /src/gone\nHEAD 3333333333333333333333333333333333333333\nbranch refs/heads/gone\nprunable gitdir file points to non-existent location\n\n" +
+		"worktree /src/held\nHEAD 4444444444444444444444444444444444444444\nbranch refs/heads/held\nlocked\n\n" +
+		"worktree /src/quoted\nHEAD 5555555555555555555555555555555555555555\nbranch refs/heads/quoted\nlocked \"two\\nlines\"\n"
+
+	got := parseWorktrees(out)
+	want := []Worktree{
+		{Path: "/src/app", HEAD: "1111111111111111111111111111111111111111", Branch: "main"},
+		{Path: "/src/usb", HEAD: "2222222222222222222222222222222222222222", Branch: "usb", Locked: true, LockReason: "on usb drive"},
+		{Path: "/src/gone", HEAD: "3333333333333333333333333333333333333333", Branch: "gone", Prunable: true, PruneReason: "gitdir file points to non-existent location"},
+		{Path: "/src/held", HEAD: "4444444444444444444444444444444444444444", Branch: "held", Locked: true},
+		{Path: "/src/quoted", HEAD: "5555555555555555555555555555555555555555", Branch: "quoted", Locked: true, LockReason: "two\nlines"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseWorktrees:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestLockWorktree(t *testing.T) {
+	r := gittest.New(t)
+	r.On("worktree", "lock", "--reason", "on usb drive", "/src/usb").Return("")
+	r.On("worktree", "lock", "/src/held").Fail("fatal: '/src/held' is already locked\n")
+
+	c := New(r)
+	if err := c.LockWorktree("/src/app", "/src/usb", "on usb drive"); err != nil {
+		t.Fatal(err)
+	}
+	err := c.LockWorktree("/src/app", "/src/held", "")
+	if err == nil || err.Error() != "'/src/held' is already locked" {
+		t.Fatalf("LockWorktree error = %v", err)
+	}
+}
+
+func TestUnlockWorktree(t *testing.T) {
+	r := gittest.New(t)
+	r.On("worktree", "unlock", "/src/usb").Return("")
+
+	if err := New(r).UnlockWorktree("/src/app", "/src/usb"); err != nil {
+		t.Fatal(err)
+	}
+	if !r.Called("worktree", "unlock", "/src/usb") {
+		t.Fatal("expected git worktree unlock")
+	}
+}
+
+func TestPruneWorktrees(t *testing.T) {
+	r := gittest.New(t)
+	r.On("worktree", "prune", "-v").Return("Removing worktrees/gone: gitdir file points to non-existent location\n" +
+		"Removing worktrees/old: gitdir file points to non-existent location\n")
+
+	got, err := New(r).PruneWorktrees("/src/app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"gone", "old"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("PruneWorktrees = %v, want %v", got, want)
+	}
+}
+
+func TestRemoveLockedWorktree(t *testing.T) {
+	r := gittest.New(t)
+	r.On("worktree", "remove", "/src/usb").Fail("fatal: cannot remove a locked working tree, lock reason: on usb drive\n" +
+		"use 'remove -f -f' to override or unlock first\n")
+
+	err := New(r).RemoveWorktree("/src/app", "/src/usb", "usb", false)
+	if err == nil || !strings.Contains(err.Error(), `"usb" is locked`) {
+		t.Fatalf("RemoveWorktree error = %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MergeStrategy selects how a branch is brought into the default branch.
+type MergeStrategy int
+
+const (
+	MergeFastForward MergeStrategy = iota
+	MergeCommit
+	MergeSquash
+)
+
+func (s MergeStrategy) String() string {
+	switch s {
+	case MergeCommit:
+		return "merge commit"
+	case MergeSquash:
+		return "squash"
+	default:
+		return "fast-forward"
+	}
+}
+
+// DefaultBranch returns the name of the repository's default branch.
+func (c *Client) DefaultBranch(repoPath string) string {
+	return c.detectDefaultBranch(repoPath, c.Remotes(repoPath).Base)
+}
+
+// MergeIntoDefault merges branch into the default branch, which must be
+// checked out and clean in the main worktree at repoPath. In a bare
+// repository the merge runs in whichever worktree has the default branch
+// checked out. A conflicting merge is rolled back and reported as an error.
+func (c *Client) MergeIntoDefault(repoPath, branch string, strategy MergeStrategy) error {
+	defaultBranch := c.DefaultBranch(repoPath)
+	if _, bare := c.BareDir(repoPath); bare {
+		wtPath, err := c.workTreeOn(repoPath, defaultBranch)
+		if err != nil {
+			return err
+		}
+		repoPath = wtPath
+	}
+
+	out, err := c.runner.Output(repoPath, "symbolic-ref", "--short", "-q", "HEAD")
+	if current := strings.TrimSpace(string(out)); err != nil || current != defaultBranch {
+		if current == "" {
+			current = "a detached HEAD"
+		}
+		return fmt.Errorf("main worktree is on %s, not %s", current, defaultBranch)
+	}
+	st, err := c.Status(repoPath)
+	if err != nil {
+		return err
+	}
+	if st.Staged > 0 || st.Unstaged > 0 || st.Conflicted > 0 || st.Operation != "" {
+		return fmt.Errorf("main worktree has uncommitted changes")
+	}
+
+	switch strategy {
+	case MergeFastForward:
+		if out, err := c.runner.CombinedOutput(repoPath, "merge", "--ff-only", branch); err != nil {
+			return fmt.Errorf("cannot fast-forward %s to %s — rebase it first (%s)", defaultBranch, branch, lastLine(out))
+		}
+	case MergeCommit:
+		if out, err := c.runner.CombinedOutput(repoPath, "merge", "--no-ff", "--no-edit", branch); err != nil {
+			c.runner.CombinedOutput(repoPath, "merge", "--abort")
+			return fmt.Errorf("merge conflicts — merge aborted (%s)", lastLine(out))
+		}
+	case MergeSquash:
+		if out, err := c.runner.CombinedOutput(repoPath, "merge", "--squash", branch); err != nil {
+			// --squash leaves no MERGE_HEAD, so merge --abort cannot undo it.
+			c.runner.CombinedOutput(repoPath, "reset", "--merge")
+			return fmt.Errorf("merge conflicts — merge aborted (%s)", lastLine(out))
+		}
+		if out, err := c.runner.CombinedOutput(repoPath, "commit", "--no-edit"); err != nil {
+			c.runner.CombinedOutput(repoPath, "reset", "--merge")
+			return fmt.Errorf("squash commit failed: %s", lastLine(out))
+		}
+	}
+	return nil
+}
+
+// lastLine returns the last non-empty line of git output, which is usually
+// the one that explains a failure.
+func lastLine(out []byte) string {
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -0,0 +1,100 @@
+package git
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/marcellolins/mossy/internal/git/gittest"
+)
+
+func mergeRunner(t *testing.T) *gittest.Runner {
+	r := originRunner(t)
+	r.On("rev-parse", "--is-bare-repository", "--absolute-git-dir").Return("false\n/src/app/.git\n")
+	r.On("symbolic-ref", "refs/remotes/origin/HEAD").Return("refs/remotes/origin/main\n")
+	r.On("symbolic-ref", "--short", "-q", "HEAD").Return("main\n")
+	r.On("status", "--porcelain=v2", "--branch", "-z").Return("# branch.head main\x00? scratch.txt\x00")
+	r.On("rev-parse", "--absolute-git-dir").Return(t.TempDir() + "\n")
+	return r
+}
+
+func TestMergeIntoDefaultStrategies(t *testing.T) {
+	tests := []struct {
+		strategy MergeStrategy
+		calls    [][]string
+	}{
+		{MergeFastForward, [][]string{{"merge", "--ff-only", "feat"}}},
+		{MergeCommit, [][]string{{"merge", "--no-ff", "--no-edit", "feat"}}},
+		{MergeSquash, [][]string{{"merge", "--squash", "feat"}, {"commit", "--no-edit"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.strategy.String(), func(t *testing.T) {
+			r := mergeRunner(t)
+			for _, c := range tt.calls {
+				r.On(c...).Return("")
+			}
+			if err := New(r).MergeIntoDefault("/src/app", "feat", tt.strategy); err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range tt.calls {
+				if !r.Called(c...) {
+					t.Errorf("expected git %s", strings.Join(c, " "))
+				}
+			}
+		})
+	}
+}
+
+func TestMergeIntoDefaultRequiresDefaultBranch(t *testing.T) {
+	r := originRunner(t)
+	r.On("rev-parse", "--is-bare-repository", "--absolute-git-dir").Return("false\n/src/app/.git\n")
+	r.On("symbolic-ref", "refs/remotes/origin/HEAD").Return("refs/remotes/origin/main\n")
+	r.On("symbolic-ref", "--short", "-q", "HEAD").Return("other\n")
+
+	err := New(r).MergeIntoDefault("/src/app", "feat", MergeCommit)
+	if err == nil || err.Error() != "main worktree is on other, not main" {
+		t.Fatalf("MergeIntoDefault error = %v", err)
+	}
+}
+
+func TestMergeIntoDefaultRejectsDirtyMainWorktree(t *testing.T) {
+	r := originRunner(t)
+	r.On("rev-parse", "--is-bare-repository", "--absolute-git-dir").Return("false\n/src/app/.git\n")
+	r.On("symbolic-ref", "refs/remotes/origin/HEAD").Return("refs/remotes/origin/main\n")
+	r.On("symbolic-ref", "--short", "-q", "HEAD").Return("main\n")
+	r.On("status", "--porcelain=v2", "--branch", "-z").Return("1 .M N... 100644 100644 100644 a b main.go\x00")
+	r.On("rev-parse", "--absolute-git-dir").Return(t.TempDir() + "\n")
+
+	if err := New(r).MergeIntoDefault("/src/app", "feat", MergeCommit); err == nil {
+		t.Fatal("expected dirty main worktree to be rejected")
+	}
+	if r.Called("merge", "--no-ff", "--no-edit", "feat") {
+		t.Fatal("merge should not run on a dirty worktree")
+	}
+}
+
+func TestMergeIntoDefaultAbortsOnConflict(t *testing.T) {
+	r := mergeRunner(t)
+	r.On("merge", "--no-ff", "--no-edit", "feat").Fail("CONFLICT (content): Merge conflict in a.go\nAutomatic merge failed; fix conflicts and then commit the result.\n")
+	r.On("merge", "--abort").Return("")
+
+	err := New(r).MergeIntoDefault("/src/app", "feat", MergeCommit)
+	if err == nil || !strings.Contains(err.Error(), "Automatic merge failed") {
+		t.Fatalf("MergeIntoDefault error = %v", err)
+	}
+	if !r.Called("merge", "--abort") {
+		t.Fatal("expected merge to be aborted")
+	}
+}
+
+func TestMergeIntoDefaultSquashConflictResets(t *testing.T) {
+	r := mergeRunner(t)
+	r.On("merge", "--squash", "feat").Fail("CONFLICT (content): Merge conflict in a.go\n")
+	r.On("reset", "--merge").Return("")
+
+	if err := New(r).MergeIntoDefault("/src/app", "feat", MergeSquash); err == nil {
+		t.Fatal("expected squash conflict to fail")
+	}
+	if !r.Called("reset", "--merge") || r.Called("commit", "--no-edit") {
+		t.Fatal("expected squash to be rolled back without committing")
+	}
+}
@@ -0,0 +1,63 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type MoveWorktreeOptions struct {
+	// Path is the new worktree directory. It must not exist yet.
+	Path string
+	// Branch is the branch currently checked out in the worktree.
+	Branch string
+	// NewBranch renames Branch when set and different from it.
+	NewBranch string
+}
+
+// MoveWorktree moves the worktree at wtPath to opts.Path and optionally
+// renames its branch. The directory is renamed in place, so shells that
+// are already inside it keep working. moved reports whether the directory
+// moved, which it may have even when renaming the branch then failed.
+func (c *Client) MoveWorktree(repoPath, wtPath string, opts MoveWorktreeOptions) (moved bool, err error) {
+	name := filepath.Base(wtPath)
+	if opts.Path != wtPath {
+		if _, err := os.Stat(opts.Path); err == nil {
+			return false, fmt.Errorf("%s already exists", opts.Path)
+		}
+		if err := os.MkdirAll(filepath.Dir(opts.Path), 0o755); err != nil {
+			return false, err
+		}
+		out, err := c.runner.CombinedOutput(repoPath, "worktree", "move", wtPath, opts.Path)
+		if err != nil {
+			if strings.Contains(string(out), "cannot move a locked working tree") {
+				return false, fmt.Errorf("worktree %q is locked; unlock it first", name)
+			}
+			return false, fmt.Errorf("%s", fatalLine(out))
+		}
+		moved = true
+	}
+
+	if opts.NewBranch == "" || opts.NewBranch == opts.Branch {
+		return moved, nil
+	}
+	if opts.Branch == "" || opts.Branch == "(detached)" {
+		return moved, fmt.Errorf("worktree %q has no branch to rename", name)
+	}
+	out, err := c.runner.CombinedOutput(repoPath, "branch", "-m", opts.Branch, opts.NewBranch)
+	if err == nil {
+		return moved, nil
+	}
+	msg := fatalLine(out)
+	switch {
+	case strings.Contains(msg, "already exists"):
+		msg = fmt.Sprintf("a branch named %q already exists", opts.NewBranch)
+	case strings.Contains(msg, "not a valid branch name"):
+		msg = fmt.Sprintf("%q is not a valid branch name", opts.NewBranch)
+	}
+	if moved {
+		return moved, fmt.Errorf("worktree moved but renaming the branch failed: %s", msg)
+	}
+	return moved, fmt.Errorf("%s", msg)
+}
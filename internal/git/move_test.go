@@ -0,0 +1,87 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/marcellolins/mossy/internal/git/gittest"
+)
+
+func TestMoveWorktreeAndRenameBranch(t *testing.T) {
+	dir := t.TempDir()
+	oldPath := filepath.Join(dir, "feat")
+	newPath := filepath.Join(dir, "nested", "feat-2")
+
+	r := gittest.New(t)
+	r.On("worktree", "move", oldPath, newPath).Return("")
+	r.On("branch", "-m", "feat", "feat-2").Return("")
+
+	moved, err := New(r).MoveWorktree("/src/app", oldPath, MoveWorktreeOptions{Path: newPath, Branch: "feat", NewBranch: "feat-2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !moved {
+		t.Error("moved = false after git worktree move succeeded")
+	}
+	if _, err := os.Stat(filepath.Dir(newPath)); err != nil {
+		t.Errorf("parent of the new path was not created: %v", err)
+	}
+	if !r.Called("branch", "-m", "feat", "feat-2") {
+		t.Error("expected the branch to be renamed")
+	}
+}
+
+func TestMoveWorktreeBranchOnly(t *testing.T) {
+	r := gittest.New(t)
+	r.On("branch", "-m", "feat", "feat-2").Return("")
+
+	moved, err := New(r).MoveWorktree("/src/app", "/src/feat", MoveWorktreeOptions{Path: "/src/feat", Branch: "feat", NewBranch: "feat-2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if moved {
+		t.Error("moved = true for a branch-only rename")
+	}
+	for _, call := range r.Calls() {
+		if strings.HasPrefix(call.String(), "worktree move") {
+			t.Fatalf("unexpected %s", call)
+		}
+	}
+}
+
+func TestMoveWorktreeExistingDestination(t *testing.T) {
+	dir := t.TempDir()
+	r := gittest.New(t)
+
+	_, err := New(r).MoveWorktree("/src/app", "/src/feat", MoveWorktreeOptions{Path: dir, Branch: "feat"})
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("MoveWorktree error = %v", err)
+	}
+	if len(r.Calls()) != 0 {
+		t.Fatalf("expected no git calls, got %v", r.Calls())
+	}
+}
+
+func TestMoveWorktreeErrors(t *testing.T) {
+	dir := t.TempDir()
+	newPath := filepath.Join(dir, "feat-2")
+
+	r := gittest.New(t)
+	r.On("worktree", "move", "/src/usb", newPath).Fail("fatal: cannot move a locked working tree, lock reason: usb\n" +
+		"use 'move -f -f' to override or unlock first\n")
+	r.On("worktree", "move", "/src/feat", newPath).Return("")
+	r.On("branch", "-m", "feat", "main").Fail("fatal: a branch named 'main' already exists\n")
+
+	c := New(r)
+	moved, err := c.MoveWorktree("/src/app", "/src/usb", MoveWorktreeOptions{Path: newPath, Branch: "usb"})
+	if moved || err == nil || err.Error() != `worktree "usb" is locked; unlock it first` {
+		t.Errorf("locked move = %v, %v", moved, err)
+	}
+	moved, err = c.MoveWorktree("/src/app", "/src/feat", MoveWorktreeOptions{Path: newPath, Branch: "feat", NewBranch: "main"})
+	want := `worktree moved but renaming the branch failed: a branch named "main" already exists`
+	if !moved || err == nil || err.Error() != want {
+		t.Errorf("rename = %v, %v, want moved with %q", moved, err, want)
+	}
+}
@@ -0,0 +1,109 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type PushOptions struct {
+	// ForceWithLease overwrites the remote branch, but only if it still
+	// points where our remote-tracking ref says it does. Needed after a
+	// rebase rewrote commits that were already pushed.
+	ForceWithLease bool
+}
+
+// PushRejectedError is returned when the remote refuses the update.
+type PushRejectedError struct {
+	Ref string
+	// Reason is git's short reason, e.g. "fetch first" or "stale info".
+	Reason string
+}
+
+func (e *PushRejectedError) Error() string {
+	switch {
+	case e.Reason == "fetch first" || e.Reason == "non-fast-forward":
+		return "push rejected: the remote has commits this branch does not — update it first, or force push"
+	case e.Reason == "stale info":
+		return "push rejected: the remote branch moved since the last fetch — fetch and check before forcing"
+	case strings.HasPrefix(e.Reason, "remote rejected"):
+		return "push rejected by the remote: " + strings.TrimPrefix(e.Reason, "remote rejected: ")
+	default:
+		return "push rejected: " + e.Reason
+	}
+}
+
+// PushResult describes a successful push.
+type PushResult struct {
+	// SetUpstream is true when this push created the upstream.
+	SetUpstream bool
+	// Remote is the remote the upstream was created on; empty when the
+	// branch already had one.
+	Remote string
+	// Summary is git's summary of the update, e.g. "[new branch]" or
+	// "1a2b3c4..5d6e7f8".
+	Summary string
+}
+
+// Push pushes the branch checked out in wtPath. A branch without an
+// upstream is pushed to the repository's push remote under its own name
+// and tracks it from then on.
+func (c *Client) Push(repoPath, wtPath, branch string, opts PushOptions) (PushResult, error) {
+	if branch == "" || branch == "(detached)" {
+		return PushResult{}, fmt.Errorf("cannot push a detached HEAD")
+	}
+	args := []string{"push", "--porcelain"}
+	if opts.ForceWithLease {
+		args = append(args, "--force-with-lease")
+	}
+	var res PushResult
+	if _, err := c.runner.Output(wtPath, "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}"); err != nil {
+		res.SetUpstream = true
+		res.Remote = c.Remotes(repoPath).Push
+		args = append(args, "--set-upstream", res.Remote, branch)
+	}
+
+	out, err := c.runner.CombinedOutput(wtPath, args...)
+	status, ref, summary := parsePushPorcelain(string(out))
+	if status == "!" {
+		return PushResult{}, &PushRejectedError{Ref: ref, Reason: rejectionReason(summary)}
+	}
+	if err != nil {
+		return PushResult{}, fmt.Errorf("push failed: %s", fatalLine(out))
+	}
+	res.Summary = summary
+	return res, nil
+}
+
+// parsePushPorcelain returns the flag, destination ref and summary of the
+// first ref line of `git push --porcelain` output, which looks like
+// "<flag>\t<src>:<dst>\t<summary>".
+func parsePushPorcelain(output string) (flag, ref, summary string) {
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 || len(fields[0]) != 1 {
+			continue
+		}
+		_, dst, _ := strings.Cut(fields[1], ":")
+		return fields[0], dst, fields[2]
+	}
+	return "", "", ""
+}
+
+var pushSummary = regexp.MustCompile(`^\[([^\]]+)\](?: \((.*)\))?$`)
+
+// rejectionReason turns "[rejected] (fetch first)" into "fetch first" and
+// "[remote rejected] (hook declined)" into "remote rejected: hook declined".
+func rejectionReason(summary string) string {
+	m := pushSummary.FindStringSubmatch(summary)
+	switch {
+	case m == nil:
+		return summary
+	case m[1] == "remote rejected" && m[2] != "":
+		return "remote rejected: " + m[2]
+	case m[2] != "":
+		return m[2]
+	default:
+		return m[1]
+	}
+}
@@ -0,0 +1,123 @@
+package git
+
+import (
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/marcellolins/mossy/internal/git/gittest"
+)
+
+func TestRejectionReason(t *testing.T) {
+	tests := map[string]string{
+		"[rejected] (fetch first)":                      "fetch first",
+		"[rejected] (stale info)":                       "stale info",
+		"[remote rejected] (pre-receive hook declined)": "remote rejected: pre-receive hook declined",
+		"[no match]": "no match",
+	}
+	for summary, want := range tests {
+		if got := rejectionReason(summary); got != want {
+			t.Errorf("rejectionReason(%q) = %q, want %q", summary, got, want)
+		}
+	}
+}
+
+func TestPushSetsUpstreamOnFirstPush(t *testing.T) {
+	r := originRunner(t)
+	r.On("rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}").Fail("fatal: no upstream configured for branch 'feat'\n")
+	r.On("push", "--porcelain", "--set-upstream", "origin", "feat").
+		Return("To example.com:app.git\n*\trefs/heads/feat:refs/heads/feat\t[new branch]\nDone\n")
+
+	res, err := New(r).Push("/src/app", "/src/feat", "feat", PushOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.SetUpstream || res.Remote != "origin" || res.Summary != "[new branch]" {
+		t.Fatalf("Push = %+v", res)
+	}
+}
+
+func TestPushRejected(t *testing.T) {
+	r := gittest.New(t)
+	r.On("rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}").Return("origin/feat\n")
+	r.On("push", "--porcelain", "--force-with-lease").
+		Fail("error: failed to push some refs\nTo example.com:app.git\n!\trefs/heads/feat:refs/heads/feat\t[rejected] (stale info)\nDone\n")
+
+	_, err := New(r).Push("/src/app", "/src/feat", "feat", PushOptions{ForceWithLease: true})
+	var rejected *PushRejectedError
+	if !errors.As(err, &rejected) {
+		t.Fatalf("Push error = %v, want *PushRejectedError", err)
+	}
+	if rejected.Ref != "refs/heads/feat" || rejected.Reason != "stale info" {
+		t.Fatalf("rejection = %+v", rejected)
+	}
+}
+
+// TestPushAgainstBareRemote runs real git against a local bare repository:
+// first push sets the upstream, diverged and rewritten pushes are rejected,
+// and --force-with-lease goes through once the remote state is known.
+func TestPushAgainstBareRemote(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GIT_CONFIG_GLOBAL", "/dev/null")
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_AUTHOR_NAME", "mossy")
+	t.Setenv("GIT_AUTHOR_EMAIL", "mossy@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "mossy")
+	t.Setenv("GIT_COMMITTER_EMAIL", "mossy@example.com")
+
+	dir := t.TempDir()
+	remote := filepath.Join(dir, "remote.git")
+	mine := filepath.Join(dir, "mine")
+	theirs := filepath.Join(dir, "theirs")
+	run := func(dir string, args ...string) {
+		t.Helper()
+		if out, err := (ExecRunner{}).CombinedOutput(dir, args...); err != nil {
+			t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+	run(dir, "init", "-q", "--bare", "-b", "main", remote)
+	run(dir, "clone", "-q", remote, mine)
+	run(mine, "commit", "-q", "--allow-empty", "-m", "initial")
+	run(mine, "push", "-q", "origin", "HEAD:main")
+	run(mine, "switch", "-q", "-c", "feat")
+	run(mine, "commit", "-q", "--allow-empty", "-m", "feat 1")
+
+	c := NewClient()
+	res, err := c.Push(mine, mine, "feat", PushOptions{})
+	if err != nil {
+		t.Fatalf("first push: %v", err)
+	}
+	if !res.SetUpstream {
+		t.Error("first push should set the upstream")
+	}
+
+	run(dir, "clone", "-q", "-b", "feat", remote, theirs)
+	run(theirs, "commit", "-q", "--allow-empty", "-m", "theirs")
+	run(theirs, "push", "-q")
+	run(mine, "commit", "-q", "--allow-empty", "-m", "feat 2")
+
+	_, err = c.Push(mine, mine, "feat", PushOptions{})
+	var rejected *PushRejectedError
+	if !errors.As(err, &rejected) || rejected.Reason != "fetch first" {
+		t.Fatalf("diverged push error = %v, want fetch first rejection", err)
+	}
+
+	run(mine, "fetch", "-q")
+	run(mine, "rebase", "-q", "origin/feat")
+	run(mine, "reset", "-q", "--soft", "HEAD~2")
+	run(mine, "commit", "-q", "--allow-empty", "-m", "theirs and feat 2, squashed")
+	if _, err := c.Push(mine, mine, "feat", PushOptions{}); !errors.As(err, &rejected) || rejected.Reason != "non-fast-forward" {
+		t.Fatalf("rewritten push error = %v, want non-fast-forward rejection", err)
+	}
+	res, err = c.Push(mine, mine, "feat", PushOptions{ForceWithLease: true})
+	if err != nil {
+		t.Fatalf("force push: %v", err)
+	}
+	if res.SetUpstream || !strings.Contains(res.Summary, "forced update") {
+		t.Fatalf("force push = %+v", res)
+	}
+}
@@ -0,0 +1,112 @@
+package git
+
+import (
+	"strings"
+	"sync"
+)
+
+// Remotes names the remotes mossy works with in a repository. Base holds
+// the default branch that worktrees are updated from and compared against;
+// Push receives the worktrees' branches. In a fork workflow Base is
+// usually "upstream" and Push "origin"; otherwise both are "origin".
+type Remotes struct {
+	Base string
+	Push string
+}
+
+type remoteSettings struct {
+	mu        sync.RWMutex
+	overrides map[string]Remotes
+	// resolved remembers the remotes of each repository until the next
+	// ListWorktrees, so a refresh only reads the git config once.
+	resolved map[string]Remotes
+}
+
+// SetRemotes overrides the detected remotes of a repository. Empty fields
+// keep their detected value.
+func (c *Client) SetRemotes(repoPath string, r Remotes) {
+	c.remotes.mu.Lock()
+	defer c.remotes.mu.Unlock()
+	if c.remotes.overrides == nil {
+		c.remotes.overrides = make(map[string]Remotes)
+	}
+	c.remotes.overrides[repoPath] = r
+	delete(c.remotes.resolved, repoPath)
+}
+
+// forgetRemotes drops the remembered remotes of a repository so the next
+// call to Remotes reads them again.
+func (c *Client) forgetRemotes(repoPath string) {
+	c.remotes.mu.Lock()
+	defer c.remotes.mu.Unlock()
+	delete(c.remotes.resolved, repoPath)
+}
+
+// Remotes returns the base and push remotes of a repository: the values
+// set with SetRemotes, or else detected from its git config. The base
+// remote is "upstream" when there is one, then "origin", then the first
+// remote listed. The push remote is remote.pushDefault when set, then
+// "origin", then the base remote. The result is remembered until the
+// repository's worktrees are listed again.
+func (c *Client) Remotes(repoPath string) Remotes {
+	c.remotes.mu.RLock()
+	r, ok := c.remotes.resolved[repoPath]
+	c.remotes.mu.RUnlock()
+	if ok {
+		return r
+	}
+	r = c.resolveRemotes(repoPath)
+	c.remotes.mu.Lock()
+	if c.remotes.resolved == nil {
+		c.remotes.resolved = make(map[string]Remotes)
+	}
+	c.remotes.resolved[repoPath] = r
+	c.remotes.mu.Unlock()
+	return r
+}
+
+func (c *Client) resolveRemotes(repoPath string) Remotes {
+	c.remotes.mu.RLock()
+	r := c.remotes.overrides[repoPath]
+	c.remotes.mu.RUnlock()
+	if r.Base != "" && r.Push != "" {
+		return r
+	}
+
+	var names []string
+	if out, err := c.runner.Output(repoPath, "remote"); err == nil {
+		names = strings.Fields(string(out))
+	}
+	has := func(name string) bool {
+		for _, n := range names {
+			if n == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	if r.Base == "" {
+		switch {
+		case has("upstream"):
+			r.Base = "upstream"
+		case has("origin") || len(names) == 0:
+			r.Base = "origin"
+		default:
+			r.Base = names[0]
+		}
+	}
+	if r.Push == "" {
+		if out, err := c.runner.Output(repoPath, "config", "--get", "remote.pushDefault"); err == nil {
+			r.Push = strings.TrimSpace(string(out))
+		}
+	}
+	if r.Push == "" {
+		if has("origin") {
+			r.Push = "origin"
+		} else {
+			r.Push = r.Base
+		}
+	}
+	return r
+}
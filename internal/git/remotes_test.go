@@ -0,0 +1,111 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/marcellolins/mossy/internal/git/gittest"
+)
+
+// originRunner returns a runner scripted for a repository whose only
+// remote is origin, without remote.pushDefault.
+func originRunner(t *testing.T) *gittest.Runner {
+	r := gittest.New(t)
+	r.On("remote").Return("origin\n")
+	r.On("config", "--get", "remote.pushDefault").Fail("")
+	return r
+}
+
+func TestRemotes(t *testing.T) {
+	tests := []struct {
+		name        string
+		remotes     string
+		pushDefault string
+		want        Remotes
+	}{
+		{"origin only", "origin\n", "", Remotes{Base: "origin", Push: "origin"}},
+		{"fork", "origin\nupstream\n", "", Remotes{Base: "upstream", Push: "origin"}},
+		{"push default", "origin\nupstream\n", "mine\n", Remotes{Base: "upstream", Push: "mine"}},
+		{"other names", "github\ngitlab\n", "", Remotes{Base: "github", Push: "github"}},
+		{"no remotes", "", "", Remotes{Base: "origin", Push: "origin"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gittest.New(t)
+			r.On("remote").Return(tt.remotes)
+			if tt.pushDefault != "" {
+				r.On("config", "--get", "remote.pushDefault").Return(tt.pushDefault)
+			} else {
+				r.On("config", "--get", "remote.pushDefault").Fail("")
+			}
+			if got := New(r).Remotes("/src/app"); got != tt.want {
+				t.Fatalf("Remotes = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetRemotesOverridesDetection(t *testing.T) {
+	r := gittest.New(t)
+	r.On("remote").Return("origin\nupstream\n")
+	r.On("config", "--get", "remote.pushDefault").Fail("")
+	c := New(r)
+
+	c.SetRemotes("/src/app", Remotes{Push: "fork"})
+	if got := c.Remotes("/src/app"); got != (Remotes{Base: "upstream", Push: "fork"}) {
+		t.Fatalf("partial override: Remotes = %+v", got)
+	}
+
+	c.SetRemotes("/src/app", Remotes{Base: "origin", Push: "fork"})
+	before := len(r.Calls())
+	if got := c.Remotes("/src/app"); got != (Remotes{Base: "origin", Push: "fork"}) {
+		t.Fatalf("full override: Remotes = %+v", got)
+	}
+	if len(r.Calls()) != before {
+		t.Error("a full override should not run git")
+	}
+}
+
+func TestRemotesRemembered(t *testing.T) {
+	r := originRunner(t)
+	r.On("worktree", "list", "--porcelain").Return("worktree /src/app\nHEAD 1111111111111111111111111111111111111111\nbranch refs/heads/main\n\n")
+	r.On("symbolic-ref", "refs/remotes/origin/HEAD").Return("refs/remotes/origin/main\n")
+	r.On("rev-parse", "--verify", "-q", "refs/remotes/origin/main").Return("1111111111111111111111111111111111111111\n")
+	r.On("status", "--porcelain=v2", "--branch", "-z").Return("# branch.head main\x00")
+	r.On("rev-parse", "--absolute-git-dir").Return(t.TempDir() + "\n")
+	c := New(r)
+	countRemote := func() int {
+		n := 0
+		for _, call := range r.Calls() {
+			if call.String() == "remote" {
+				n++
+			}
+		}
+		return n
+	}
+
+	c.Remotes("/src/app")
+	c.Remotes("/src/app")
+	if n := countRemote(); n != 1 {
+		t.Fatalf("remotes read %d times, want once", n)
+	}
+	if _, err := c.ListWorktrees("/src/app"); err != nil {
+		t.Fatal(err)
+	}
+	c.Remotes("/src/app")
+	if n := countRemote(); n != 2 {
+		t.Errorf("remotes read %d times, want once more for the listing", n)
+	}
+}
+
+func TestUpdateWorktreeUsesBaseRemote(t *testing.T) {
+	r := gittest.New(t)
+	r.On("remote").Return("origin\nupstream\n")
+	r.On("config", "--get", "remote.pushDefault").Fail("")
+	r.On("symbolic-ref", "refs/remotes/upstream/HEAD").Return("refs/remotes/upstream/main\n")
+	r.On("fetch", "upstream", "main").Return("")
+	r.On("rebase", "upstream/main").Return("")
+
+	if err := New(r).UpdateWorktree("/src/app", "/src/feat", UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+}
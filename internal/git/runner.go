@@ -0,0 +1,98 @@
+package git
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// Runner executes a git subcommand in dir. Output returns stdout only;
+// CombinedOutput interleaves stdout and stderr, which is what we want when
+// the text ends up in an error message. Stream hands back stdout while the
+// command is still running; closing it waits for the exit status.
+// StreamCombined does the same for stdout and stderr together, which is
+// where git writes --progress output. It is meant for commands that run in
+// the background: env is added to the environment and the process is
+// killed once ctx is done.
+type Runner interface {
+	Output(dir string, args ...string) ([]byte, error)
+	CombinedOutput(dir string, args ...string) ([]byte, error)
+	Stream(dir string, args ...string) (io.ReadCloser, error)
+	StreamCombined(ctx context.Context, dir string, env []string, args ...string) (io.ReadCloser, error)
+}
+
+// ExecRunner runs the git binary found on PATH.
+type ExecRunner struct{}
+
+func (ExecRunner) Output(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	return cmd.Output()
+}
+
+func (ExecRunner) CombinedOutput(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	return cmd.CombinedOutput()
+}
+
+// streamReader exposes a running command's stdout and reaps the process
+// when closed.
+type streamReader struct {
+	io.ReadCloser
+	cmd *exec.Cmd
+}
+
+func (s *streamReader) Close() error {
+	s.ReadCloser.Close()
+	return s.cmd.Wait()
+}
+
+func (ExecRunner) Stream(dir string, args ...string) (io.ReadCloser, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return &streamReader{ReadCloser: stdout, cmd: cmd}, nil
+}
+
+// combinedStream reads from a pipe shared by stdout and stderr. The pipe is
+// closed once the process exits and Close reports its exit status.
+type combinedStream struct {
+	*io.PipeReader
+	done chan error
+}
+
+func (s *combinedStream) Close() error {
+	s.PipeReader.Close()
+	return <-s.done
+}
+
+func (ExecRunner) StreamCombined(ctx context.Context, dir string, env []string, args ...string) (io.ReadCloser, error) {
+	pr, pw := io.Pipe()
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	// Helpers such as ssh can outlive a killed git and keep the pipe open;
+	// stop waiting for them shortly after.
+	cmd.WaitDelay = 5 * time.Second
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	done := make(chan error, 1)
+	go func() {
+		err := cmd.Wait()
+		pw.Close()
+		done <- err
+	}()
+	return &combinedStream{PipeReader: pr, done: done}, nil
+}
@@ -0,0 +1,138 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Stash is an entry of the stash list. Stashes are shared by every
+// worktree of a repository; Branch records where each one was made.
+type Stash struct {
+	// Ref is the stash@{n} name; it shifts as entries are added or dropped.
+	Ref     string
+	Hash    string
+	Branch  string
+	Message string
+	Date    string
+}
+
+const stashListFormat = "--format=%gd%x1f%H%x1f%gs%x1f%cr"
+
+// ListStashes returns the repository's stashes, newest first.
+func (c *Client) ListStashes(wtPath string) ([]Stash, error) {
+	out, err := c.runner.Output(wtPath, "stash", "list", "-z", stashListFormat)
+	if err != nil {
+		return nil, err
+	}
+	return parseStashes(string(out)), nil
+}
+
+func parseStashes(output string) []Stash {
+	var stashes []Stash
+	for _, rec := range strings.Split(output, "\x00") {
+		fields := strings.Split(rec, "\x1f")
+		if len(fields) < 4 {
+			continue
+		}
+		s := Stash{Ref: fields[0], Hash: fields[1], Message: fields[2], Date: fields[3]}
+		// The reflog subject is "WIP on <branch>: <hash> <subject>" for
+		// plain stashes and "On <branch>: <message>" for named ones.
+		rest, ok := strings.CutPrefix(fields[2], "WIP on ")
+		if !ok {
+			rest, ok = strings.CutPrefix(fields[2], "On ")
+		}
+		if ok {
+			if branch, msg, ok := strings.Cut(rest, ": "); ok {
+				s.Branch = branch
+				s.Message = msg
+			}
+		}
+		stashes = append(stashes, s)
+	}
+	return stashes
+}
+
+// StashDiff returns the stat and patch of a stash, untracked files included.
+func (c *Client) StashDiff(wtPath, ref string) (string, error) {
+	out, err := c.runner.CombinedOutput(wtPath, "stash", "show", "--stat", "-p", "--include-untracked", ref)
+	if err != nil {
+		return "", fmt.Errorf("%s", fatalLine(out))
+	}
+	return string(out), nil
+}
+
+// StashPush stashes the worktree's changes, untracked files included.
+func (c *Client) StashPush(wtPath, message string) error {
+	args := []string{"stash", "push", "--include-untracked"}
+	if message != "" {
+		args = append(args, "-m", message)
+	}
+	out, err := c.runner.CombinedOutput(wtPath, args...)
+	if err != nil {
+		return fmt.Errorf("%s", fatalLine(out))
+	}
+	if strings.Contains(string(out), "No local changes to save") {
+		return fmt.Errorf("no local changes to stash")
+	}
+	return nil
+}
+
+// ErrStashesChanged is returned when a stash is no longer at the ref it
+// was listed under, because entries were pushed or dropped since.
+var ErrStashesChanged = errors.New("stash list changed, reload")
+
+// checkStash makes sure s.Ref still names the stash that was listed, so
+// an action never hits a neighbouring entry after the list shifted.
+func (c *Client) checkStash(wtPath string, s Stash) error {
+	out, err := c.runner.Output(wtPath, "rev-parse", "--verify", "-q", s.Ref)
+	if err != nil || strings.TrimSpace(string(out)) != s.Hash {
+		return ErrStashesChanged
+	}
+	return nil
+}
+
+// StashApply applies a stash to the worktree and keeps it in the list.
+func (c *Client) StashApply(wtPath string, s Stash) error {
+	return c.stashApply(wtPath, "apply", s)
+}
+
+// StashPop applies a stash to the worktree and drops it. git keeps the
+// stash when applying it conflicts.
+func (c *Client) StashPop(wtPath string, s Stash) error {
+	return c.stashApply(wtPath, "pop", s)
+}
+
+func (c *Client) stashApply(wtPath, verb string, s Stash) error {
+	if err := c.checkStash(wtPath, s); err != nil {
+		return err
+	}
+	out, err := c.runner.CombinedOutput(wtPath, "stash", verb, s.Ref)
+	if err == nil {
+		return nil
+	}
+	if files, ferr := c.ConflictedFiles(wtPath); ferr == nil && len(files) > 0 {
+		return fmt.Errorf("%s conflicts in %d file(s); %s was kept", s.Ref, len(files), s.Ref)
+	}
+	return fmt.Errorf("%s", stashError(out))
+}
+
+// StashDrop deletes a stash.
+func (c *Client) StashDrop(wtPath string, s Stash) error {
+	if err := c.checkStash(wtPath, s); err != nil {
+		return err
+	}
+	if out, err := c.runner.CombinedOutput(wtPath, "stash", "drop", s.Ref); err != nil {
+		return fmt.Errorf("%s", fatalLine(out))
+	}
+	return nil
+}
+
+// stashError picks the useful part of a failed apply: git lists the files
+// that would be overwritten between a header and "Aborting".
+func stashError(out []byte) string {
+	if strings.Contains(string(out), "would be overwritten") {
+		return "local changes would be overwritten; commit or stash them first"
+	}
+	return fatalLine(out)
+}
@@ -0,0 +1,93 @@
+package git
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/marcellolins/mossy/internal/git/gittest"
+)
+
+func TestListStashes(t *testing.T) {
+	r := gittest.New(t)
+	r.On("stash", "list", "-z", stashListFormat).Return(
+		"stash@{0}\x1f167b851f\x1fOn feat/x: half-done refactor\x1f2 minutes ago\x00" +
+			"stash@{1}\x1f1632d1b3\x1fWIP on main: 6cbdb4b add f\x1f3 days ago\x00")
+
+	got, err := New(r).ListStashes("/src/feat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Stash{
+		{Ref: "stash@{0}", Hash: "167b851f", Branch: "feat/x", Message: "half-done refactor", Date: "2 minutes ago"},
+		{Ref: "stash@{1}", Hash: "1632d1b3", Branch: "main", Message: "6cbdb4b add f", Date: "3 days ago"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ListStashes:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestStashPush(t *testing.T) {
+	r := gittest.New(t)
+	r.On("stash", "push", "--include-untracked", "-m", "wip").Return("Saved working directory and index state On feat: wip\n")
+	r.On("stash", "push", "--include-untracked").Return("No local changes to save\n")
+
+	c := New(r)
+	if err := c.StashPush("/src/feat", "wip"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.StashPush("/src/feat", ""); err == nil || err.Error() != "no local changes to stash" {
+		t.Fatalf("StashPush error = %v", err)
+	}
+}
+
+func TestStashPopConflicts(t *testing.T) {
+	r := gittest.New(t)
+	r.On("rev-parse", "--verify", "-q", "stash@{0}").Return("aaa\n")
+	r.On("stash", "pop", "stash@{0}").Fail("Auto-merging f\nCONFLICT (content): Merge conflict in f\nThe stash entry is kept in case you need it again.\n")
+	r.On("diff", "--name-only", "--diff-filter=U", "-z").Return("f\x00")
+
+	err := New(r).StashPop("/src/feat", Stash{Ref: "stash@{0}", Hash: "aaa"})
+	if err == nil || err.Error() != "stash@{0} conflicts in 1 file(s); stash@{0} was kept" {
+		t.Fatalf("StashPop error = %v", err)
+	}
+}
+
+func TestStashApplyWouldOverwrite(t *testing.T) {
+	r := gittest.New(t)
+	r.On("rev-parse", "--verify", "-q", "stash@{1}").Return("bbb\n")
+	r.On("stash", "apply", "stash@{1}").Fail("error: The following untracked working tree files would be overwritten by merge:\n\tf\n" +
+		"Please move or remove them before you merge.\nAborting\n")
+	r.On("diff", "--name-only", "--diff-filter=U", "-z").Return("")
+
+	err := New(r).StashApply("/src/feat", Stash{Ref: "stash@{1}", Hash: "bbb"})
+	if err == nil || err.Error() != "local changes would be overwritten; commit or stash them first" {
+		t.Fatalf("StashApply error = %v", err)
+	}
+}
+
+func TestStashDrop(t *testing.T) {
+	r := gittest.New(t)
+	r.On("rev-parse", "--verify", "-q", "stash@{9}").Return("ccc\n")
+	r.On("stash", "drop", "stash@{9}").Fail("fatal: log for 'stash' only has 3 entries\n")
+
+	err := New(r).StashDrop("/src/feat", Stash{Ref: "stash@{9}", Hash: "ccc"})
+	if err == nil || err.Error() != "log for 'stash' only has 3 entries" {
+		t.Fatalf("StashDrop error = %v", err)
+	}
+}
+
+func TestStashDropShiftedList(t *testing.T) {
+	// Another worktree pushed a stash since the list was loaded, so
+	// stash@{1} is now the entry that used to be stash@{0}.
+	r := gittest.New(t)
+	r.On("rev-parse", "--verify", "-q", "stash@{1}").Return("aaa\n")
+
+	err := New(r).StashDrop("/src/feat", Stash{Ref: "stash@{1}", Hash: "bbb"})
+	if !errors.Is(err, ErrStashesChanged) {
+		t.Fatalf("StashDrop error = %v, want ErrStashesChanged", err)
+	}
+	if r.Called("stash", "drop", "stash@{1}") {
+		t.Error("dropped a stash other than the one selected")
+	}
+}
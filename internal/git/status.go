@@ -0,0 +1,116 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Status summarises the uncommitted state of a worktree.
+type Status struct {
+	Staged     int
+	Unstaged   int
+	Untracked  int
+	Conflicted int
+	// Operation names an in-progress rebase, merge, cherry-pick or revert,
+	// or is empty when none is running.
+	Operation string
+	// Upstream is the branch's tracking ref (e.g. "origin/feat"), empty
+	// when none is configured. Ahead and Behind are relative to it.
+	Upstream string
+	Ahead    int
+	Behind   int
+}
+
+// Clean reports whether the worktree has no local changes at all.
+func (s Status) Clean() bool {
+	return s.Staged == 0 && s.Unstaged == 0 && s.Untracked == 0 && s.Conflicted == 0
+}
+
+// Status reads the working-tree state of the worktree at wtPath.
+func (c *Client) Status(wtPath string) (Status, error) {
+	out, err := c.runner.Output(wtPath, "status", "--porcelain=v2", "--branch", "-z")
+	if err != nil {
+		return Status{}, err
+	}
+	st := parseStatus(string(out))
+	if gitDir, err := c.runner.Output(wtPath, "rev-parse", "--absolute-git-dir"); err == nil {
+		st.Operation = detectOperation(strings.TrimSpace(string(gitDir)))
+	}
+	return st, nil
+}
+
+// parseStatus counts entries in `git status --porcelain=v2 --branch -z`
+// output. Ordinary ("1") and rename/copy ("2") entries carry an XY pair where
+// X is the index state and Y the worktree state, '.' meaning unchanged.
+func parseStatus(output string) Status {
+	var st Status
+	tokens := strings.Split(output, "\x00")
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		if len(tok) < 2 {
+			continue
+		}
+		switch tok[0] {
+		case '#':
+			parseBranchHeader(tok, &st)
+		case '1', '2':
+			if tok[0] == '2' {
+				// The original path follows as its own token.
+				i++
+			}
+			if len(tok) < 4 {
+				continue
+			}
+			if tok[2] != '.' {
+				st.Staged++
+			}
+			if tok[3] != '.' {
+				st.Unstaged++
+			}
+		case 'u':
+			st.Conflicted++
+		case '?':
+			st.Untracked++
+		}
+	}
+	return st
+}
+
+// parseBranchHeader reads the "# branch.upstream" and "# branch.ab +A -B"
+// headers; the rest are ignored.
+func parseBranchHeader(line string, st *Status) {
+	if upstream, ok := strings.CutPrefix(line, "# branch.upstream "); ok {
+		st.Upstream = upstream
+		return
+	}
+	if ab, ok := strings.CutPrefix(line, "# branch.ab "); ok {
+		fields := strings.Fields(ab)
+		if len(fields) != 2 {
+			return
+		}
+		st.Ahead, _ = strconv.Atoi(strings.TrimPrefix(fields[0], "+"))
+		st.Behind, _ = strconv.Atoi(strings.TrimPrefix(fields[1], "-"))
+	}
+}
+
+// detectOperation looks for the state files git leaves in the worktree's
+// private git directory while a multi-step operation is paused.
+func detectOperation(gitDir string) string {
+	for _, op := range []struct {
+		file string
+		name string
+	}{
+		{"rebase-merge", "rebase"},
+		{"rebase-apply", "rebase"},
+		{"MERGE_HEAD", "merge"},
+		{"CHERRY_PICK_HEAD", "cherry-pick"},
+		{"REVERT_HEAD", "revert"},
+	} {
+		if _, err := os.Stat(filepath.Join(gitDir, op.file)); err == nil {
+			return op.name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,73 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/marcellolins/mossy/internal/git/gittest"
+)
+
+func TestParseStatus(t *testing.T) {
+	out := "1 MM N... 100644 100644 100644 7898192 9327e29 a\x00" +
+		"2 R. N... 100644 100644 100644 2795c87 2795c87 R100 d\x00c\x00" +
+		"1 .D N... 100644 100644 000000 1111111 1111111 gone.txt\x00" +
+		"u UU N... 100644 100644 100644 100644 aaa bbb ccc conflict.go\x00" +
+		"? untracked.txt\x00" +
+		"? other dir/file with spaces\x00"
+
+	got := parseStatus(out)
+	want := Status{Staged: 2, Unstaged: 2, Untracked: 2, Conflicted: 1}
+	if got != want {
+		t.Fatalf("parseStatus = %+v, want %+v", got, want)
+	}
+	if got.Clean() {
+		t.Fatal("status with changes reported clean")
+	}
+	if !parseStatus("").Clean() {
+		t.Fatal("empty status should be clean")
+	}
+}
+
+func TestParseStatusBranchHeaders(t *testing.T) {
+	out := "# branch.oid abc\x00# branch.head feat\x00# branch.upstream origin/feat\x00# branch.ab +3 -12\x00"
+	got := parseStatus(out)
+	want := Status{Upstream: "origin/feat", Ahead: 3, Behind: 12}
+	if got != want {
+		t.Fatalf("parseStatus = %+v, want %+v", got, want)
+	}
+	if !got.Clean() {
+		t.Fatal("branch headers alone should not make the worktree dirty")
+	}
+}
+
+func TestStatusDetectsRebase(t *testing.T) {
+	gitDir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(gitDir, "rebase-merge"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	r := gittest.New(t)
+	r.On("status", "--porcelain=v2", "--branch", "-z").Return("u UU N... 100644 100644 100644 100644 a b c main.go\x00")
+	r.On("rev-parse", "--absolute-git-dir").Return(gitDir + "\n")
+
+	st, err := New(r).Status("/src/feat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.Operation != "rebase" || st.Conflicted != 1 {
+		t.Fatalf("Status = %+v", st)
+	}
+}
+
+func TestDetectOperation(t *testing.T) {
+	gitDir := t.TempDir()
+	if got := detectOperation(gitDir); got != "" {
+		t.Fatalf("idle worktree reported %q", got)
+	}
+	if err := os.WriteFile(filepath.Join(gitDir, "MERGE_HEAD"), []byte("abc\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := detectOperation(gitDir); got != "merge" {
+		t.Fatalf("detectOperation = %q, want merge", got)
+	}
+}
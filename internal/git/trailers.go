@@ -0,0 +1,64 @@
+package git
+
+import (
+	"net/textproto"
+	"regexp"
+	"strings"
+)
+
+// Trailers holds the trailers of a commit message, such as
+// "Signed-off-by: Ada <ada@example.com>", in the order they appear. Keys
+// are stored in canonical form ("Signed-Off-By"), so look them up with Get.
+type Trailers map[string][]string
+
+// Get returns the values of the trailers with the given key, ignoring case.
+func (t Trailers) Get(key string) []string {
+	return t[textproto.CanonicalMIMEHeaderKey(key)]
+}
+
+// parseTrailers reads the output of %(trailers:only,unfold): one "Key: value"
+// line per trailer.
+func parseTrailers(s string) Trailers {
+	var t Trailers
+	for _, line := range strings.Split(s, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || key == "" || value == "" {
+			continue
+		}
+		if t == nil {
+			t = make(Trailers)
+		}
+		key = textproto.CanonicalMIMEHeaderKey(key)
+		t[key] = append(t[key], value)
+	}
+	return t
+}
+
+// issueTrailers are the trailer keys whose values point at issues.
+var issueTrailers = []string{"Fixes", "Closes", "Resolves", "Refs", "Issue"}
+
+// issueRef matches GitHub-style references: "#12" or "owner/repo#12".
+var issueRef = regexp.MustCompile(`(?:^|[\s(\[,])((?:[\w.-]+/[\w.-]+)?#\d+)\b`)
+
+// issueRefs collects the issues a commit refers to: the values of its
+// issue trailers, then the "#12" references in its subject and body.
+func issueRefs(c Commit) []string {
+	var refs []string
+	seen := make(map[string]bool)
+	add := func(ref string) {
+		if !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+	for _, key := range issueTrailers {
+		for _, v := range c.Trailers.Get(key) {
+			add(v)
+		}
+	}
+	for _, m := range issueRef.FindAllStringSubmatch(c.Subject+"\n"+c.Body, -1) {
+		add(m[1])
+	}
+	return refs
+}
@@ -0,0 +1,44 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Captured from `git log --format=%(trailers:only,unfold)` on a commit with
+// a folded Co-authored-by line.
+const trailerOutput = "Signed-off-by: Ada <ada@x.com>\n" +
+	"Co-authored-by: Bob Builder <bob@x.com>\n" +
+	"Co-authored-by: Claude <noreply@anthropic.com>\n" +
+	"Reviewed-by: Cy <cy@x.com>\n" +
+	"Fixes: #7\n"
+
+func TestParseTrailers(t *testing.T) {
+	tr := parseTrailers(trailerOutput)
+	if got := tr.Get("co-authored-by"); !reflect.DeepEqual(got, []string{"Bob Builder <bob@x.com>", "Claude <noreply@anthropic.com>"}) {
+		t.Errorf("Co-authored-by = %v", got)
+	}
+	if got := tr.Get("Signed-off-by"); !reflect.DeepEqual(got, []string{"Ada <ada@x.com>"}) {
+		t.Errorf("Signed-off-by = %v", got)
+	}
+	if got := tr.Get("REVIEWED-BY"); !reflect.DeepEqual(got, []string{"Cy <cy@x.com>"}) {
+		t.Errorf("Reviewed-by = %v", got)
+	}
+	if parseTrailers("") != nil || parseTrailers("not a trailer\n") != nil {
+		t.Error("expected no trailers")
+	}
+}
+
+func TestParseCommitHeaderTrailers(t *testing.T) {
+	c := parseCommitHeader("aaa\x1fFix login (#42)\x1fAda\x1fada@x.com\x1fada@x.com\x1f1 hour ago\x1f\x1f"+
+		"Closes #7, see acme/api#9 and issue#3\n\n"+trailerOutput+"\x1f"+trailerOutput, defaultAgentDetector)
+	if !reflect.DeepEqual(c.AIAgents, []string{"Claude"}) {
+		t.Errorf("AIAgents = %v", c.AIAgents)
+	}
+	if !reflect.DeepEqual(c.CoAuthors, []string{"Bob Builder <bob@x.com>"}) {
+		t.Errorf("CoAuthors = %v", c.CoAuthors)
+	}
+	if want := []string{"#7", "#42", "acme/api#9"}; !reflect.DeepEqual(c.Issues, want) {
+		t.Errorf("Issues = %v, want %v", c.Issues, want)
+	}
+}
@@ -0,0 +1,138 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// UpdateStrategy selects how a worktree catches up with the default branch.
+// The values double as the names used in config.json.
+type UpdateStrategy string
+
+const (
+	UpdateRebase      UpdateStrategy = "rebase"
+	UpdateAutostash   UpdateStrategy = "autostash"
+	UpdateMerge       UpdateStrategy = "merge"
+	UpdateFastForward UpdateStrategy = "ff-only"
+)
+
+// UpdateStrategies lists the strategies in the order they are offered.
+var UpdateStrategies = []UpdateStrategy{UpdateRebase, UpdateAutostash, UpdateMerge, UpdateFastForward}
+
+// ParseUpdateStrategy maps a config value to a strategy, falling back to
+// UpdateRebase for empty or unknown values.
+func ParseUpdateStrategy(name string) UpdateStrategy {
+	for _, s := range UpdateStrategies {
+		if string(s) == name {
+			return s
+		}
+	}
+	return UpdateRebase
+}
+
+// Describe returns the git operation the strategy runs, for status lines.
+func (s UpdateStrategy) Describe() string {
+	switch s {
+	case UpdateAutostash:
+		return "rebase --autostash"
+	case UpdateMerge:
+		return "merge"
+	case UpdateFastForward:
+		return "fast-forward only"
+	default:
+		return "rebase"
+	}
+}
+
+type UpdateOptions struct {
+	Strategy UpdateStrategy
+	// KeepConflicts leaves a conflicting rebase or merge in progress and
+	// returns a *ConflictError instead of rolling it back.
+	KeepConflicts bool
+}
+
+// UpdateWorktree fetches the latest default branch from the base remote
+// and brings the worktree's branch up to date with it using opts.Strategy.
+// If a rebase or merge encounters conflicts it is automatically aborted
+// and an error is returned, unless opts.KeepConflicts is set. Failures
+// without conflicts, such as local changes in the way, return git's error.
+func (c *Client) UpdateWorktree(repoPath, wtPath string, opts UpdateOptions) error {
+	remote := c.Remotes(repoPath).Base
+	defaultBranch := c.detectDefaultBranch(repoPath, remote)
+	upstream := remote + "/" + defaultBranch
+
+	if out, err := c.runner.CombinedOutput(wtPath, "fetch", remote, defaultBranch); err != nil {
+		return fmt.Errorf("fetch failed: %s", strings.TrimSpace(string(out)))
+	}
+
+	var args []string
+	operation := "rebase"
+	switch opts.Strategy {
+	case UpdateAutostash:
+		args = []string{"rebase", "--autostash", upstream}
+	case UpdateMerge:
+		args = []string{"merge", "--no-edit", upstream}
+		operation = "merge"
+	case UpdateFastForward:
+		if out, err := c.runner.CombinedOutput(wtPath, "merge", "--ff-only", upstream); err != nil {
+			return fmt.Errorf("cannot fast-forward — branch has diverged from %s (%s)", upstream, lastLine(out))
+		}
+		return nil
+	default:
+		args = []string{"rebase", upstream}
+	}
+
+	out, err := c.runner.CombinedOutput(wtPath, args...)
+	if err == nil {
+		return nil
+	}
+	files, _ := c.ConflictedFiles(wtPath)
+	if len(files) == 0 {
+		// git refused to start, most often over local changes. Only roll
+		// back if it got as far as leaving the operation in progress.
+		if c.operationInProgress(wtPath) == operation {
+			c.runner.CombinedOutput(wtPath, operation, "--abort")
+		}
+		return updateFailedError(operation, out)
+	}
+	if opts.KeepConflicts {
+		return &ConflictError{Operation: operation, Files: files, Output: strings.TrimSpace(string(out))}
+	}
+	c.runner.CombinedOutput(wtPath, operation, "--abort")
+	return fmt.Errorf("conflicts detected — resolve in terminal (%s)", strings.TrimSpace(string(out)))
+}
+
+// operationInProgress returns the rebase, merge or other operation that
+// is stopped in the worktree, or "".
+func (c *Client) operationInProgress(wtPath string) string {
+	gitDir, err := c.runner.Output(wtPath, "rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return ""
+	}
+	return detectOperation(strings.TrimSpace(string(gitDir)))
+}
+
+var localChanges = regexp.MustCompile(`(?i)unstaged changes|uncommitted changes|local changes`)
+
+// updateFailedError reports a rebase or merge that failed without
+// conflicts using git's own first error line, pointing at the autostash
+// strategy when local changes were in the way.
+func updateFailedError(operation string, out []byte) error {
+	msg := lastLine(out)
+	for _, line := range strings.Split(string(out), "\n") {
+		line = strings.TrimSpace(line)
+		if m, ok := strings.CutPrefix(line, "error: "); ok {
+			msg = m
+			break
+		}
+		if m, ok := strings.CutPrefix(line, "fatal: "); ok {
+			msg = m
+			break
+		}
+	}
+	if localChanges.MatchString(string(out)) {
+		return fmt.Errorf("%s failed: %s — commit or stash your changes, or update with autostash", operation, msg)
+	}
+	return fmt.Errorf("%s failed: %s", operation, msg)
+}
@@ -0,0 +1,102 @@
+package git
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/marcellolins/mossy/internal/git/gittest"
+)
+
+func updateRunner(t *testing.T) *gittest.Runner {
+	r := originRunner(t)
+	r.On("symbolic-ref", "refs/remotes/origin/HEAD").Return("refs/remotes/origin/main\n")
+	r.On("fetch", "origin", "main").Return("")
+	return r
+}
+
+func TestUpdateWorktreeStrategies(t *testing.T) {
+	tests := []struct {
+		strategy UpdateStrategy
+		args     []string
+	}{
+		{UpdateRebase, []string{"rebase", "origin/main"}},
+		{UpdateAutostash, []string{"rebase", "--autostash", "origin/main"}},
+		{UpdateMerge, []string{"merge", "--no-edit", "origin/main"}},
+		{UpdateFastForward, []string{"merge", "--ff-only", "origin/main"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			r := updateRunner(t)
+			r.On(tt.args...).Return("")
+			if err := New(r).UpdateWorktree("/src/app", "/src/feat", UpdateOptions{Strategy: tt.strategy}); err != nil {
+				t.Fatal(err)
+			}
+			if !r.Called(tt.args...) {
+				t.Fatalf("expected git %s", strings.Join(tt.args, " "))
+			}
+		})
+	}
+}
+
+func TestUpdateWorktreeMergeConflicts(t *testing.T) {
+	r := updateRunner(t)
+	r.On("merge", "--no-edit", "origin/main").Fail("CONFLICT (content): Merge conflict in a.go\n")
+	r.On("diff", "--name-only", "--diff-filter=U", "-z").Return("a.go\x00")
+	r.On("merge", "--abort").Return("")
+
+	c := New(r)
+	var conflictErr *ConflictError
+	err := c.UpdateWorktree("/src/app", "/src/feat", UpdateOptions{Strategy: UpdateMerge, KeepConflicts: true})
+	if !errors.As(err, &conflictErr) || conflictErr.Operation != "merge" {
+		t.Fatalf("UpdateWorktree error = %v, want merge conflicts", err)
+	}
+	if r.Called("merge", "--abort") {
+		t.Fatal("merge should be left in progress")
+	}
+
+	if err := c.UpdateWorktree("/src/app", "/src/feat", UpdateOptions{Strategy: UpdateMerge}); err == nil {
+		t.Fatal("expected an error")
+	}
+	if !r.Called("merge", "--abort") {
+		t.Fatal("expected merge to be aborted")
+	}
+}
+
+func TestUpdateWorktreeDirtyMerge(t *testing.T) {
+	r := updateRunner(t)
+	r.On("merge", "--no-edit", "origin/main").Fail("error: Your local changes to the following files would be overwritten by merge:\n" +
+		"\tmain.go\nPlease commit your changes or stash them before you merge.\nAborting\n")
+	r.On("diff", "--name-only", "--diff-filter=U", "-z").Return("")
+	r.On("rev-parse", "--absolute-git-dir").Return(t.TempDir() + "\n")
+
+	err := New(r).UpdateWorktree("/src/app", "/src/feat", UpdateOptions{Strategy: UpdateMerge})
+	if err == nil || strings.Contains(err.Error(), "conflicts") || !strings.Contains(err.Error(), "autostash") {
+		t.Fatalf("UpdateWorktree error = %v, want git's error and an autostash hint", err)
+	}
+	if r.Called("merge", "--abort") {
+		t.Error("a merge that never started should not be aborted")
+	}
+}
+
+func TestUpdateWorktreeFastForwardDiverged(t *testing.T) {
+	r := updateRunner(t)
+	r.On("merge", "--ff-only", "origin/main").Fail("hint: Diverging branches can't be fast-forwarded\nfatal: Not possible to fast-forward, aborting.\n")
+
+	err := New(r).UpdateWorktree("/src/app", "/src/feat", UpdateOptions{Strategy: UpdateFastForward})
+	if err == nil || !strings.Contains(err.Error(), "diverged") {
+		t.Fatalf("UpdateWorktree error = %v", err)
+	}
+}
+
+func TestParseUpdateStrategy(t *testing.T) {
+	if got := ParseUpdateStrategy("ff-only"); got != UpdateFastForward {
+		t.Errorf("ff-only = %q", got)
+	}
+	if got := ParseUpdateStrategy(""); got != UpdateRebase {
+		t.Errorf("empty = %q, want rebase", got)
+	}
+	if got := ParseUpdateStrategy("yolo"); got != UpdateRebase {
+		t.Errorf("unknown = %q, want rebase", got)
+	}
+}
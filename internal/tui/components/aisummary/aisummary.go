@@ -0,0 +1,135 @@
+package aisummary
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/marcellolins/mossy/internal/git"
+)
+
+// maxCommits bounds how much of the default branch's history is counted.
+const maxCommits = 500
+
+// Height is the number of lines the panel takes up.
+const Height = 2
+
+type StatsFetchedMsg struct {
+	RepoPath string
+	Stats    git.AIStats
+	Err      error
+}
+
+func FetchStats(client *git.Client, repoPath string) tea.Cmd {
+	return func() tea.Msg {
+		stats, err := client.RepoAIStats(repoPath, maxCommits)
+		return StatsFetchedMsg{RepoPath: repoPath, Stats: stats, Err: err}
+	}
+}
+
+var (
+	labelStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#8FBC8F")).
+			Bold(true)
+
+	countStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("245"))
+
+	agentStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#B48EAD")).
+			Bold(true)
+
+	humanStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("252"))
+
+	shareStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("252"))
+
+	dimStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240"))
+
+	errStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF5555"))
+)
+
+// Model summarises who made the commits of a repository: the recent
+// history of its default branch and the commits its worktrees' branches
+// add on top of it.
+type Model struct {
+	history  git.AIStats
+	branches git.AIStats
+	loaded   bool
+	err      error
+}
+
+func New() Model {
+	return Model{}
+}
+
+// SetWorktrees adds up the AI statistics of the worktrees' branches.
+func (m *Model) SetWorktrees(wts []git.Worktree) {
+	m.branches = git.AIStats{}
+	for _, wt := range wts {
+		m.branches.Merge(wt.AI)
+	}
+}
+
+// Reset forgets the statistics of the previous repository.
+func (m *Model) Reset() {
+	*m = Model{}
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if msg, ok := msg.(StatsFetchedMsg); ok {
+		m.loaded = true
+		m.history = msg.Stats
+		m.err = msg.Err
+	}
+	return m, nil
+}
+
+// renderShares lists each agent's and the humans' share of commits and of
+// changed lines.
+func renderShares(s git.AIStats) string {
+	if s.Total.Commits == 0 {
+		return dimStyle.Render("no commits")
+	}
+	var parts []string
+	for _, name := range s.AgentNames() {
+		c := s.Agents[name]
+		parts = append(parts, agentStyle.Render("🤖 "+name)+" "+
+			shareStyle.Render(fmt.Sprintf("%d%%/%d%%", s.CommitShare(c), s.LineShare(c))))
+	}
+	parts = append(parts, humanStyle.Render("Human")+" "+
+		shareStyle.Render(fmt.Sprintf("%d%%/%d%%", s.CommitShare(s.Human), s.LineShare(s.Human))))
+	return strings.Join(parts, dimStyle.Render(" · "))
+}
+
+func (m Model) row(label string, count, body string, width int) string {
+	line := "  " + labelStyle.Width(16).Render(label) +
+		countStyle.Width(14).Render(count) + body
+	return lipgloss.NewStyle().MaxWidth(width).Render(line)
+}
+
+func (m Model) View(width int) string {
+	var history string
+	switch {
+	case !m.loaded:
+		history = dimStyle.Render("counting…")
+	case m.err != nil:
+		history = errStyle.Render(fmt.Sprintf("Error: %v", m.err))
+	default:
+		history = renderShares(m.history) + dimStyle.Render("   (% of commits/lines)")
+	}
+	historyCount := ""
+	switch n := m.history.Total.Commits; {
+	case !m.loaded || m.err != nil:
+	case n == maxCommits:
+		historyCount = fmt.Sprintf("last %d", n)
+	default:
+		historyCount = fmt.Sprintf("%d commits", n)
+	}
+	return m.row("Default branch", historyCount, history, width) + "\n" +
+		m.row("Worktrees", fmt.Sprintf("%d commits", m.branches.Total.Commits), renderShares(m.branches), width)
+}
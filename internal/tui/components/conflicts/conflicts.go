@@ -0,0 +1,271 @@
+package conflicts
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/marcellolins/mossy/internal/git"
+)
+
+// ConflictsFetchedMsg carries the files that are still unmerged. Reset
+// starts a fresh list, which happens when a rebase moves on to a commit
+// with conflicts of its own.
+type ConflictsFetchedMsg struct {
+	Files []string
+	Reset bool
+	Err   error
+}
+
+func FetchConflicts(client *git.Client, wtPath string, reset bool) tea.Cmd {
+	return func() tea.Msg {
+		files, err := client.ConflictedFiles(wtPath)
+		return ConflictsFetchedMsg{Files: files, Reset: reset, Err: err}
+	}
+}
+
+type OpenFileMsg struct {
+	Path string
+}
+
+type MarkResolvedMsg struct {
+	File string
+}
+
+// ActionMsg asks for the paused rebase or merge to be continued, skipped
+// or aborted.
+type ActionMsg struct {
+	Action string
+}
+
+type ConflictsClosedMsg struct{}
+
+const (
+	ActionContinue = "continue"
+	ActionSkip     = "skip"
+	ActionAbort    = "abort"
+)
+
+var (
+	titleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#8FBC8F")).
+			Padding(0, 1)
+
+	cursorStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Background(lipgloss.Color("236")).
+			Bold(true)
+
+	conflictStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF5555"))
+
+	resolvedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#8FBC8F"))
+
+	fileStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("252"))
+
+	statusStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFBD2E")).
+			Bold(true).
+			Padding(0, 1)
+
+	helpStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240")).
+			Padding(0, 2)
+
+	errStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF5555")).
+			Padding(0, 1)
+)
+
+type file struct {
+	path     string
+	resolved bool
+}
+
+type Model struct {
+	wtPath string
+	// operation is "rebase" or "merge"; merges have no commit to skip.
+	operation string
+	files     []file
+	cursor    int
+	err       error
+	width     int
+	height    int
+	// Busy is set while a git command started from this view is running.
+	Busy string
+}
+
+// New opens the view for a paused "rebase" or "merge"; other operations
+// cannot be continued from it.
+func New(wtPath, operation string, width, height int) Model {
+	return Model{wtPath: wtPath, operation: operation, width: width, height: height}
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// WtPath returns the worktree whose conflicts are being resolved.
+func (m Model) WtPath() string {
+	return m.wtPath
+}
+
+// Operation returns the paused git operation, "rebase" or "merge".
+func (m Model) Operation() string {
+	return m.operation
+}
+
+// SetError shows a failure from the last action without leaving the view.
+func (m *Model) SetError(err error) {
+	m.err = err
+	m.Busy = ""
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) unresolved() int {
+	n := 0
+	for _, f := range m.files {
+		if !f.resolved {
+			n++
+		}
+	}
+	return n
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ConflictsFetchedMsg:
+		m.Busy = ""
+		m.err = msg.Err
+		if msg.Err != nil {
+			return m, nil
+		}
+		if msg.Reset {
+			m.files = nil
+			m.cursor = 0
+		}
+		pending := make(map[string]bool, len(msg.Files))
+		for _, f := range msg.Files {
+			pending[f] = true
+		}
+		// Files that dropped off the unmerged list have been resolved.
+		known := make(map[string]bool, len(m.files))
+		for i := range m.files {
+			known[m.files[i].path] = true
+			m.files[i].resolved = !pending[m.files[i].path]
+		}
+		for _, f := range msg.Files {
+			if !known[f] {
+				m.files = append(m.files, file{path: f})
+			}
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.Busy != "" {
+			return m, nil
+		}
+		switch msg.String() {
+		case "esc", "q":
+			return m, func() tea.Msg { return ConflictsClosedMsg{} }
+		case "j", "down":
+			if m.cursor < len(m.files)-1 {
+				m.cursor++
+			}
+		case "k", "up":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "enter", "e":
+			if m.cursor < len(m.files) {
+				path := filepath.Join(m.wtPath, m.files[m.cursor].path)
+				return m, func() tea.Msg { return OpenFileMsg{Path: path} }
+			}
+		case "a":
+			if m.cursor < len(m.files) && !m.files[m.cursor].resolved {
+				f := m.files[m.cursor].path
+				m.Busy = "Marking resolved…"
+				return m, func() tea.Msg { return MarkResolvedMsg{File: f} }
+			}
+		case "c":
+			if m.unresolved() > 0 {
+				m.err = fmt.Errorf("resolve all files before continuing")
+				return m, nil
+			}
+			m.Busy = "Continuing " + m.operation + "…"
+			return m, func() tea.Msg { return ActionMsg{Action: ActionContinue} }
+		case "s":
+			if m.operation != "rebase" {
+				return m, nil
+			}
+			m.Busy = "Skipping commit…"
+			return m, func() tea.Msg { return ActionMsg{Action: ActionSkip} }
+		case "A":
+			m.Busy = "Aborting " + m.operation + "…"
+			return m, func() tea.Msg { return ActionMsg{Action: ActionAbort} }
+		}
+	}
+	return m, nil
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+
+	title := fmt.Sprintf("⚠ %s conflicts in %s — %d of %d unresolved",
+		strings.ToUpper(m.operation[:1])+m.operation[1:], filepath.Base(m.wtPath), m.unresolved(), len(m.files))
+	b.WriteString(titleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	rows := m.height - 7
+	if rows < 1 {
+		rows = 1
+	}
+	start := 0
+	if m.cursor >= rows {
+		start = m.cursor - rows + 1
+	}
+	end := start + rows
+	if end > len(m.files) {
+		end = len(m.files)
+	}
+	for i := start; i < end; i++ {
+		f := m.files[i]
+		marker := conflictStyle.Render("✖ conflicted")
+		if f.resolved {
+			marker = resolvedStyle.Render("✓ resolved  ")
+		}
+		line := "  " + marker + "  " + fileStyle.Render(f.path)
+		if i == m.cursor {
+			line = cursorStyle.Render("> ") + marker + cursorStyle.Render("  "+f.path)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	for i := end - start; i < rows; i++ {
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	switch {
+	case m.Busy != "":
+		b.WriteString(statusStyle.Render("⟳ " + m.Busy))
+	case m.err != nil:
+		b.WriteString(errStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+	}
+	b.WriteString("\n")
+	help := "enter: open in $EDITOR • a: mark resolved • c: continue • s: skip commit • A: abort rebase • esc: close"
+	if m.operation == "merge" {
+		help = "enter: open in $EDITOR • a: mark resolved • c: commit merge • A: abort merge • esc: close"
+	}
+	b.WriteString(helpStyle.Render(help))
+
+	return b.String()
+}
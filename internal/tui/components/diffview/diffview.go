@@ -0,0 +1,451 @@
+package diffview
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/marcellolins/mossy/internal/git"
+)
+
+// Section is a titled part of the view, such as the staged changes of a
+// worktree. A single untitled section shows a plain patch.
+type Section struct {
+	Title string
+	Diff  string
+}
+
+// DiffFetchedMsg carries the content to show. Source is the commit hash or
+// worktree path it was loaded for; a view ignores content for another
+// source, which arrives late after the view changed. Refresh marks a live
+// update of content already on screen, which keeps the scroll position.
+type DiffFetchedMsg struct {
+	Source    string
+	Sections  []Section
+	Untracked []string
+	Refresh   bool
+	Err       error
+}
+
+func FetchCommitDiff(client *git.Client, repoPath, hash string) tea.Cmd {
+	return func() tea.Msg {
+		diff, err := client.CommitDiff(repoPath, hash)
+		return DiffFetchedMsg{Source: hash, Sections: []Section{{Diff: diff}}, Err: err}
+	}
+}
+
+// FetchWorkingChanges loads the uncommitted changes of a worktree.
+func FetchWorkingChanges(client *git.Client, wtPath string, refresh bool) tea.Cmd {
+	return func() tea.Msg {
+		wc, err := client.WorkingChanges(wtPath)
+		return DiffFetchedMsg{
+			Source: wtPath,
+			Sections: []Section{
+				{Title: "Staged", Diff: wc.Staged},
+				{Title: "Unstaged", Diff: wc.Unstaged},
+			},
+			Untracked: wc.Untracked,
+			Refresh:   refresh,
+			Err:       err,
+		}
+	}
+}
+
+type DiffClosedMsg struct{}
+
+const maxFileRows = 8
+
+var (
+	titleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#8FBC8F")).
+			Padding(0, 1)
+
+	cursorStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Background(lipgloss.Color("236")).
+			Bold(true)
+
+	fileStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("252"))
+
+	dividerStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("238"))
+
+	addStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("2"))
+
+	delStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("1"))
+
+	hunkStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("6"))
+
+	hashStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFBD2E"))
+
+	fileHeaderStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Bold(true)
+
+	metaStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("245"))
+
+	sectionStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#8FBC8F")).
+			Bold(true)
+
+	untrackedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("245"))
+
+	helpStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240")).
+			Padding(0, 2)
+
+	errStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF5555")).
+			Padding(0, 1)
+)
+
+// file is an entry of the file list: a file's section of a patch, or an
+// untracked file.
+type file struct {
+	path      string
+	label     string
+	section   string
+	line      int
+	hunks     []int
+	additions int
+	deletions int
+	binary    bool
+	untracked bool
+}
+
+// Model shows one or more patches with a list of their files above them.
+// The file under the top of the patch window is highlighted in the list;
+// [ and ] jump between files and n/N between hunks.
+type Model struct {
+	title string
+	// source is the commit hash or worktree path shown.
+	source string
+	lines  []string
+	// headings marks the lines that are section titles.
+	headings map[int]bool
+	files    []file
+	scroll   int
+	// jumpTo is the path to scroll to once the content arrives.
+	jumpTo string
+	loaded bool
+	err    error
+	width  int
+	height int
+}
+
+// New returns a view of source, the commit hash or worktree path whose
+// DiffFetchedMsg it shows.
+func New(title, source string, width, height int) Model {
+	return Model{title: title, source: source, width: width, height: height}
+}
+
+// JumpTo opens the patch at path once it has loaded, instead of at the
+// top.
+func (m *Model) JumpTo(path string) {
+	m.jumpTo = path
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// setContent lays the sections out one after another, each under its
+// title, followed by the untracked files. Empty sections are left out.
+func (m *Model) setContent(sections []Section, untracked []string) {
+	m.lines, m.files = nil, nil
+	m.headings = make(map[int]bool)
+	for _, sec := range sections {
+		if sec.Diff == "" && sec.Title != "" {
+			continue
+		}
+		if sec.Title != "" {
+			m.headings[len(m.lines)] = true
+			m.lines = append(m.lines, sec.Title)
+		}
+		offset := len(m.lines)
+		patch := git.ParsePatch(sec.Diff)
+		m.lines = append(m.lines, patch.Lines...)
+		for _, f := range patch.Files {
+			label := f.Path
+			if f.OldPath != "" {
+				label = f.OldPath + " → " + f.Path
+			}
+			hunks := make([]int, len(f.Hunks))
+			for i, h := range f.Hunks {
+				hunks[i] = h + offset
+			}
+			m.files = append(m.files, file{
+				path:      f.Path,
+				label:     label,
+				section:   sec.Title,
+				line:      f.Start + offset,
+				hunks:     hunks,
+				additions: f.Additions,
+				deletions: f.Deletions,
+				binary:    f.Binary,
+			})
+		}
+	}
+	if len(untracked) > 0 {
+		m.headings[len(m.lines)] = true
+		m.lines = append(m.lines, "Untracked")
+		for _, path := range untracked {
+			m.files = append(m.files, file{path: path, label: path, section: "Untracked", line: len(m.lines), untracked: true})
+			m.lines = append(m.lines, "? "+path)
+		}
+	}
+}
+
+func (m Model) fileRows() int {
+	if len(m.files) < maxFileRows {
+		return len(m.files)
+	}
+	return maxFileRows
+}
+
+// patchRows is the height of the patch window: everything but the title,
+// file list, dividers and help line.
+func (m Model) patchRows() int {
+	rows := m.height - m.fileRows() - 5
+	if rows < 1 {
+		rows = 1
+	}
+	return rows
+}
+
+func (m Model) maxScroll() int {
+	if n := len(m.lines) - m.patchRows(); n > 0 {
+		return n
+	}
+	return 0
+}
+
+func (m *Model) scrollTo(line int) {
+	m.scroll = max(0, min(line, m.maxScroll()))
+}
+
+// currentFile returns the index of the file shown at the top of the patch
+// window, or -1 while still above the first one.
+func (m Model) currentFile() int {
+	cur := -1
+	for i, f := range m.files {
+		if f.line > m.scroll {
+			break
+		}
+		cur = i
+	}
+	return cur
+}
+
+func (m Model) hunks() []int {
+	var all []int
+	for _, f := range m.files {
+		all = append(all, f.hunks...)
+	}
+	return all
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case DiffFetchedMsg:
+		if msg.Source != m.source {
+			return m, nil
+		}
+		if msg.Refresh && msg.Err != nil {
+			// Keep showing the last good content.
+			return m, nil
+		}
+		m.loaded = true
+		m.err = msg.Err
+		m.setContent(msg.Sections, msg.Untracked)
+		if msg.Refresh {
+			m.scrollTo(m.scroll)
+		} else {
+			m.scroll = 0
+			for _, f := range m.files {
+				if f.path == m.jumpTo {
+					m.scrollTo(f.line)
+					break
+				}
+			}
+			m.jumpTo = ""
+		}
+		return m, nil
+	case tea.KeyMsg:
+		half := m.patchRows() / 2
+		switch msg.String() {
+		case "esc", "q":
+			return m, func() tea.Msg { return DiffClosedMsg{} }
+		case "j", "down":
+			m.scrollTo(m.scroll + 1)
+		case "k", "up":
+			m.scrollTo(m.scroll - 1)
+		case "ctrl+d", "pgdown", " ":
+			m.scrollTo(m.scroll + max(half, 1))
+		case "ctrl+u", "pgup":
+			m.scrollTo(m.scroll - max(half, 1))
+		case "g", "home":
+			m.scrollTo(0)
+		case "G", "end":
+			m.scrollTo(m.maxScroll())
+		case "]", "tab":
+			if i := m.currentFile() + 1; i < len(m.files) {
+				m.scrollTo(m.files[i].line)
+			}
+		case "[", "shift+tab":
+			i := m.currentFile()
+			// From inside a file, go back to its own header first.
+			if i >= 0 && m.files[i].line == m.scroll {
+				i--
+			}
+			if i >= 0 {
+				m.scrollTo(m.files[i].line)
+			}
+		case "n":
+			for _, h := range m.hunks() {
+				if h > m.scroll {
+					m.scrollTo(h)
+					break
+				}
+			}
+		case "N":
+			hunks := m.hunks()
+			for i := len(hunks) - 1; i >= 0; i-- {
+				if hunks[i] < m.scroll {
+					m.scrollTo(hunks[i])
+					break
+				}
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m Model) renderFiles() []string {
+	rows := m.fileRows()
+	cur := m.currentFile()
+	start := 0
+	if cur >= rows {
+		start = cur - rows + 1
+	}
+	end := min(start+rows, len(m.files))
+	var lines []string
+	for i := start; i < end; i++ {
+		f := m.files[i]
+		name := f.label
+		var stat string
+		switch {
+		case f.untracked:
+			stat = untrackedStyle.Render("untracked")
+		case f.binary:
+			stat = metaStyle.Render("binary")
+		case f.additions > 0 || f.deletions > 0:
+			stat = addStyle.Render(fmt.Sprintf("+%d", f.additions)) + " " + delStyle.Render(fmt.Sprintf("-%d", f.deletions))
+		}
+		if f.section != "" && !f.untracked {
+			stat += metaStyle.Render("  " + strings.ToLower(f.section))
+		}
+		if i == cur {
+			lines = append(lines, cursorStyle.Render("> "+name)+"  "+stat)
+		} else {
+			lines = append(lines, fileStyle.Render("  "+name)+"  "+stat)
+		}
+	}
+	return lines
+}
+
+// renderPatch colours the visible window of the patch.
+func (m Model) renderPatch(rows int) []string {
+	end := min(m.scroll+rows, len(m.lines))
+	var lines []string
+	for i := m.scroll; i < end; i++ {
+		l := strings.ReplaceAll(m.lines[i], "\t", "    ")
+		if r := []rune(l); len(r) > m.width-2 && m.width > 3 {
+			l = string(r[:m.width-3]) + "…"
+		}
+		switch {
+		case m.headings[i]:
+			l = sectionStyle.Render("▌ " + l)
+		case strings.HasPrefix(l, "? "):
+			l = untrackedStyle.Render(l)
+		case strings.HasPrefix(l, "diff "):
+			l = fileHeaderStyle.Render(l)
+		case strings.HasPrefix(l, "commit "):
+			l = hashStyle.Render(l)
+		case strings.HasPrefix(l, "+++"), strings.HasPrefix(l, "---"), strings.HasPrefix(l, "index "):
+			l = metaStyle.Render(l)
+		case strings.HasPrefix(l, "+"):
+			l = addStyle.Render(l)
+		case strings.HasPrefix(l, "-"):
+			l = delStyle.Render(l)
+		case strings.HasPrefix(l, "@@"):
+			l = hunkStyle.Render(l)
+		}
+		lines = append(lines, " "+l)
+	}
+	return lines
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+
+	title := m.title
+	if m.loaded && len(m.files) > 0 {
+		title += fmt.Sprintf(" — %d file(s)", len(m.files))
+	}
+	b.WriteString(titleStyle.Render(title))
+	b.WriteString("\n")
+
+	divider := dividerStyle.Render(strings.Repeat("─", max(m.width, 0)))
+	rows := m.patchRows()
+	var body []string
+	switch {
+	case !m.loaded:
+		body = []string{metaStyle.Render("  Loading diff…")}
+	case m.err != nil:
+		body = []string{errStyle.Render(fmt.Sprintf("Error: %v", m.err))}
+	case len(m.lines) == 0:
+		body = []string{metaStyle.Render("  No changes")}
+	default:
+		for _, l := range m.renderFiles() {
+			b.WriteString(l)
+			b.WriteString("\n")
+		}
+		body = m.renderPatch(rows)
+	}
+	b.WriteString(divider)
+	b.WriteString("\n")
+	for len(body) < rows {
+		body = append(body, "")
+	}
+	b.WriteString(strings.Join(body, "\n"))
+	b.WriteString("\n")
+	b.WriteString(divider)
+	b.WriteString("\n")
+
+	pos := ""
+	if n := len(m.lines); n > rows {
+		pos = fmt.Sprintf("%d%% • ", 100*(m.scroll+rows)/n)
+	}
+	if cur := m.currentFile(); cur >= 0 {
+		pos += filepath.Base(m.files[cur].label) + " • "
+	}
+	b.WriteString(helpStyle.Render(pos + "j/k: scroll • ctrl+d/u: page • [/]: file • n/N: hunk • g/G: top/bottom • esc: close"))
+
+	return b.String()
+}
@@ -2,6 +2,7 @@ package footer
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -111,18 +112,21 @@ func (m Model) View() string {
 	newWt := inactiveViewStyle.UnsetPadding().Render("New Worktree (") + inactiveViewStyle.Underline(true).UnsetPadding().Render("n") + inactiveViewStyle.UnsetPadding().Render(")")
 	removeWt := inactiveViewStyle.UnsetPadding().Render("Remove Worktree (") + inactiveViewStyle.Underline(true).UnsetPadding().Render("x") + inactiveViewStyle.UnsetPadding().Render(")")
 	updateWt := inactiveViewStyle.UnsetPadding().Render("Update Worktree (") + inactiveViewStyle.Underline(true).UnsetPadding().Render("u") + inactiveViewStyle.UnsetPadding().Render(")")
+	mergeWt := inactiveViewStyle.UnsetPadding().Render("Merge (") + inactiveViewStyle.Underline(true).UnsetPadding().Render("m") + inactiveViewStyle.UnsetPadding().Render(")")
 	var tmuxLabel string
 	if m.ctx.TmuxVisiblePane != "" {
 		tmuxLabel = activeViewStyle.UnsetPadding().Render("Terminal (") + activeViewStyle.Underline(true).UnsetPadding().Render("space") + activeViewStyle.UnsetPadding().Render(")")
 	} else {
 		tmuxLabel = inactiveViewStyle.UnsetPadding().Render("Terminal (") + inactiveViewStyle.Underline(true).UnsetPadding().Render("space") + inactiveViewStyle.UnsetPadding().Render(")")
 	}
-	left := bell + sep + autoRefresh + sep + newWt + sep + removeWt + sep + updateWt + sep + tmuxLabel
+	left := bell + sep + autoRefresh + sep + newWt + sep + removeWt + sep + updateWt + sep + mergeWt + sep + tmuxLabel
 
 	// Center: message area
 	var mid string
 	if m.ctx.Message != "" {
 		mid = messageStyle.Render(m.ctx.Message)
+	} else if m.ctx.Fetch.Running {
+		mid = syncStyle.Render(m.renderFetch())
 	} else if m.ctx.Loading {
 		mid = syncStyle.Render("⟳ syncing")
 	}
@@ -156,3 +160,24 @@ func (m Model) View() string {
 
 	return bar
 }
+
+// renderFetch summarises a running background fetch: how many repositories
+// are done and the latest step of one that is still going.
+func (m Model) renderFetch() string {
+	f := m.ctx.Fetch
+	s := fmt.Sprintf("⇣ fetching %d/%d", f.Done, f.Total)
+	paths := make([]string, 0, len(f.Progress))
+	for path := range f.Progress {
+		paths = append(paths, path)
+	}
+	if len(paths) == 0 {
+		return s
+	}
+	sort.Strings(paths)
+	p := f.Progress[paths[0]]
+	s += " · " + m.ctx.RepoLabel(paths[0]) + " " + strings.ToLower(p.Phase)
+	if p.Percent >= 0 {
+		s += fmt.Sprintf(" %d%%", p.Percent)
+	}
+	return s
+}
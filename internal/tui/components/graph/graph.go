@@ -0,0 +1,244 @@
+package graph
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/marcellolins/mossy/internal/git"
+)
+
+// maxCommits bounds how much of the default branch's history is drawn.
+const maxCommits = 300
+
+type GraphFetchedMsg struct {
+	Rows []git.GraphRow
+	Err  error
+}
+
+func FetchGraph(client *git.Client, repoPath string) tea.Cmd {
+	return func() tea.Msg {
+		rows, err := client.CommitGraph(repoPath, maxCommits)
+		return GraphFetchedMsg{Rows: rows, Err: err}
+	}
+}
+
+type GraphClosedMsg struct{}
+
+// laneColors cycle across graph columns so neighbouring lanes are told
+// apart.
+var laneColors = []lipgloss.Color{"#8FBC8F", "#FFBD2E", "#88C0D0", "#B48EAD", "#D08770", "#A3BE8C"}
+
+var (
+	titleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#8FBC8F")).
+			Padding(0, 1)
+
+	cursorStyle = lipgloss.NewStyle().
+			Background(lipgloss.Color("236"))
+
+	hashStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFBD2E"))
+
+	worktreeStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#B48EAD")).
+			Bold(true)
+
+	refStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("245"))
+
+	subjectStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("252"))
+
+	metaStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240"))
+
+	helpStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240")).
+			Padding(0, 2)
+
+	errStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF5555")).
+			Padding(0, 1)
+)
+
+// Model draws the commit graph of a repository. The cursor moves between
+// commit rows; the lines connecting them scroll along.
+type Model struct {
+	repoName string
+	rows     []git.GraphRow
+	cursor   int
+	offset   int
+	loaded   bool
+	err      error
+	width    int
+	height   int
+}
+
+func New(repoName string, width, height int) Model {
+	return Model{repoName: repoName, width: width, height: height}
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.keepCursorVisible()
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) visibleRows() int {
+	rows := m.height - 4
+	if rows < 1 {
+		rows = 1
+	}
+	return rows
+}
+
+// move steps the cursor over n commits, skipping connector rows.
+func (m *Model) move(n int) {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for ; n > 0; n-- {
+		for i := m.cursor + step; i >= 0 && i < len(m.rows); i += step {
+			if m.rows[i].Hash != "" {
+				m.cursor = i
+				break
+			}
+		}
+	}
+	m.keepCursorVisible()
+}
+
+func (m *Model) keepCursorVisible() {
+	rows := m.visibleRows()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+rows {
+		m.offset = m.cursor - rows + 1
+	}
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case GraphFetchedMsg:
+		m.loaded = true
+		m.err = msg.Err
+		m.rows = msg.Rows
+		m.cursor, m.offset = 0, 0
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q":
+			return m, func() tea.Msg { return GraphClosedMsg{} }
+		case "j", "down":
+			m.move(1)
+		case "k", "up":
+			m.move(-1)
+		case "ctrl+d", "pgdown":
+			m.move(m.visibleRows() / 2)
+		case "ctrl+u", "pgup":
+			m.move(-m.visibleRows() / 2)
+		case "g", "home":
+			m.cursor, m.offset = 0, 0
+		case "G", "end":
+			m.move(len(m.rows))
+		}
+	}
+	return m, nil
+}
+
+// renderGraph colours each column of the lane drawing.
+func renderGraph(graph string, bg lipgloss.Style) string {
+	var b strings.Builder
+	for i, r := range []rune(graph) {
+		if r == ' ' {
+			b.WriteString(bg.Render(" "))
+			continue
+		}
+		style := lipgloss.NewStyle().Foreground(laneColors[(i/2)%len(laneColors)]).Inherit(bg)
+		if r == '●' {
+			style = style.Bold(true)
+		}
+		b.WriteString(style.Render(string(r)))
+	}
+	return b.String()
+}
+
+func (m Model) renderRow(i int) string {
+	row := m.rows[i]
+	bg := lipgloss.NewStyle()
+	if i == m.cursor {
+		bg = cursorStyle
+	}
+	line := bg.Render(" ") + renderGraph(row.Graph, bg)
+	if row.Hash == "" {
+		return bg.Width(m.width).MaxWidth(m.width).Render(line)
+	}
+	hash := row.Hash
+	if len(hash) > 7 {
+		hash = hash[:7]
+	}
+	line += bg.Render(" ") + hashStyle.Inherit(bg).Render(hash)
+	for _, wt := range row.Worktrees {
+		line += bg.Render(" ") + worktreeStyle.Inherit(bg).Render("["+filepath.Base(wt)+"]")
+	}
+	if row.Refs != "" {
+		line += bg.Render(" ") + refStyle.Inherit(bg).Render("("+row.Refs+")")
+	}
+	line += bg.Render(" ") + subjectStyle.Inherit(bg).Render(row.Subject) +
+		metaStyle.Inherit(bg).Render(" · "+row.Author+", "+row.Date)
+	return bg.Width(m.width).MaxWidth(m.width).Render(line)
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+
+	commits := 0
+	for _, r := range m.rows {
+		if r.Hash != "" {
+			commits++
+		}
+	}
+	title := "Commit graph — " + m.repoName
+	if m.loaded && m.err == nil {
+		title += fmt.Sprintf(" (%d commits)", commits)
+	}
+	b.WriteString(titleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	rows := m.visibleRows()
+	var lines []string
+	switch {
+	case !m.loaded:
+		lines = append(lines, metaStyle.Render("  Loading graph…"))
+	case m.err != nil:
+		lines = append(lines, errStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+	default:
+		end := min(m.offset+rows, len(m.rows))
+		for i := m.offset; i < end; i++ {
+			lines = append(lines, m.renderRow(i))
+		}
+	}
+	for len(lines) < rows {
+		lines = append(lines, "")
+	}
+	b.WriteString(strings.Join(lines, "\n"))
+	b.WriteString("\n\n")
+
+	help := "j/k: move • ctrl+d/u: page • g/G: top/bottom • esc: close"
+	if commits == maxCommits {
+		help = fmt.Sprintf("showing the newest %d commits • ", maxCommits) + help
+	}
+	b.WriteString(helpStyle.Render(help))
+
+	return b.String()
+}
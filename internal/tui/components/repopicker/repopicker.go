@@ -22,6 +22,7 @@ type dirEntry struct {
 	name      string
 	path      string
 	isGitRepo bool
+	isBare    bool
 	isAdded   bool
 }
 
@@ -110,12 +111,14 @@ func (m *Model) readDir() {
 		}
 		p := filepath.Join(m.currentDir, e.Name())
 		_, gitErr := os.Stat(filepath.Join(p, ".git"))
-		isGit := gitErr == nil
+		isBare := gitErr != nil && isBareRepo(p)
+		isGit := gitErr == nil || isBare
 		_, added := m.existing[p]
 		dirs = append(dirs, dirEntry{
 			name:      e.Name(),
 			path:      p,
 			isGitRepo: isGit,
+			isBare:    isBare,
 			isAdded:   isGit && added,
 		})
 	}
@@ -127,6 +130,20 @@ func (m *Model) readDir() {
 	m.entries = append(m.entries, dirs...)
 }
 
+// isBareRepo reports whether dir looks like a git directory the way git
+// itself checks: a HEAD file next to objects and refs directories.
+func isBareRepo(dir string) bool {
+	if info, err := os.Stat(filepath.Join(dir, "HEAD")); err != nil || info.IsDir() {
+		return false
+	}
+	for _, sub := range []string{"objects", "refs"} {
+		if info, err := os.Stat(filepath.Join(dir, sub)); err != nil || !info.IsDir() {
+			return false
+		}
+	}
+	return true
+}
+
 func (m Model) visibleRows() int {
 	rows := m.height - 6
 	if rows < 1 {
@@ -171,6 +188,9 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				// Already added — do nothing
 			} else {
 				name := filepath.Base(entry.path)
+				if entry.isBare {
+					name = strings.TrimSuffix(name, ".git")
+				}
 				path := entry.path
 				return m, func() tea.Msg {
 					return RepoSelectedMsg{Name: name, Path: path}
@@ -220,6 +240,8 @@ func (m Model) View() string {
 		line := fmt.Sprintf("%s 📁 %s", cursor, entry.name)
 		if entry.isAdded {
 			line += "  " + addedTagStyle.Render("✓ added")
+		} else if entry.isBare {
+			line += "  " + gitTagStyle.Render("✓ git (bare)")
 		} else if entry.isGitRepo {
 			line += "  " + gitTagStyle.Render("✓ git")
 		}
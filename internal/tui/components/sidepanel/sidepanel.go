@@ -65,18 +65,67 @@ var (
 			Foreground(lipgloss.Color("#B48EAD")).
 			Bold(true)
 
+	issueStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#88C0D0"))
+
 	emptyStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("245"))
+
+	aheadStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#8FBC8F"))
+
+	behindStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFBD2E"))
+
+	paneTitleStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240")).
+			Bold(true)
+
+	paneFocusStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFBD2E")).
+			Bold(true)
+
+	rowSelectedStyle = lipgloss.NewStyle().
+				Background(lipgloss.Color("236"))
+
+	separatorStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("238"))
 )
 
+// Focus is the pane that J/K move through.
+type Focus int
+
+const (
+	FocusList Focus = iota
+	FocusDetail
+)
+
+// Model shows the commits of the selected worktree in two panes: a list of
+// commits on the left and the details of the one under the cursor on the
+// right. Both scroll; Focus decides which one J/K move. Once the details
+// are scrolled down to their Files list, J/K select files in it.
 type Model struct {
 	worktree *git.Worktree
 	commits  []git.Commit
 	Cursor   int
+	Focus    Focus
+	// detailScroll is the first line of the detail pane that is shown.
+	detailScroll int
+	// fileCursor is the selected entry of the Files list, or -1.
+	fileCursor int
+	width      int
+	height     int
 }
 
 func New() Model {
-	return Model{}
+	return Model{fileCursor: -1}
+}
+
+// SetSize records the size the panel is drawn at so scrolling can stop at
+// the end of the details.
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
 }
 
 func (m *Model) SetWorktree(wt *git.Worktree) {
@@ -86,23 +135,135 @@ func (m *Model) SetWorktree(wt *git.Worktree) {
 func (m *Model) SetCommits(commits []git.Commit) {
 	m.commits = commits
 	m.Cursor = 0
+	m.detailScroll = 0
+	m.fileCursor = -1
+}
+
+// SelectedCommit returns the commit currently shown, if any.
+func (m Model) SelectedCommit() (git.Commit, bool) {
+	if m.Cursor < 0 || m.Cursor >= len(m.commits) {
+		return git.Commit{}, false
+	}
+	return m.commits[m.Cursor], true
+}
+
+// SelectedFile returns the file selected in the details' Files list, if
+// any.
+func (m Model) SelectedFile() (string, bool) {
+	c, ok := m.SelectedCommit()
+	if !ok || m.fileCursor < 0 || m.fileCursor >= len(c.Files) {
+		return "", false
+	}
+	return c.Files[m.fileCursor], true
 }
 
 func (m *Model) NextCommit() {
 	if m.Cursor < len(m.commits)-1 {
 		m.Cursor++
+		m.detailScroll = 0
+		m.fileCursor = -1
 	}
 }
 
 func (m *Model) PrevCommit() {
 	if m.Cursor > 0 {
 		m.Cursor--
+		m.detailScroll = 0
+		m.fileCursor = -1
+	}
+}
+
+// ToggleFocus moves focus between the commit list and the details.
+func (m *Model) ToggleFocus() {
+	if m.Focus == FocusList {
+		m.Focus = FocusDetail
+	} else {
+		m.Focus = FocusList
+		m.fileCursor = -1
+	}
+}
+
+// Scroll moves the focused pane by delta: the commit cursor in the list,
+// or the visible lines of the details. In the details the file cursor
+// takes over once the Files list is on screen, and hands back when moved
+// above its first entry.
+func (m *Model) Scroll(delta int) {
+	if m.Focus == FocusList {
+		for ; delta > 0; delta-- {
+			m.NextCommit()
+		}
+		for ; delta < 0; delta++ {
+			m.PrevCommit()
+		}
+		return
+	}
+	c, _ := m.SelectedCommit()
+	_, detailWidth := m.paneWidths(m.width - 2)
+	lines, filesAt := m.detailLines(detailWidth)
+	body := m.bodyHeight(m.height)
+	maxScroll := max(len(lines)-body, 0)
+	for ; delta > 0; delta-- {
+		switch {
+		case m.fileCursor >= 0:
+			m.fileCursor = min(m.fileCursor+1, len(c.Files)-1)
+		case len(c.Files) > 0 && filesAt < m.detailScroll+body:
+			m.fileCursor = 0
+		default:
+			m.detailScroll = min(m.detailScroll+1, maxScroll)
+		}
+	}
+	for ; delta < 0; delta++ {
+		if m.fileCursor >= 0 {
+			m.fileCursor--
+		} else {
+			m.detailScroll = max(m.detailScroll-1, 0)
+		}
+	}
+	if m.fileCursor >= 0 {
+		line := filesAt + m.fileCursor
+		if line >= m.detailScroll+body {
+			m.detailScroll = min(line-body+1, maxScroll)
+		}
+		if line < m.detailScroll {
+			m.detailScroll = line
+		}
 	}
 }
 
+// renderSync describes how far the worktree has drifted from the default
+// branch and from its upstream.
+func (m Model) renderSync() string {
+	if m.worktree == nil {
+		return ""
+	}
+	wt := m.worktree
+	counts := func(ahead, behind int) string {
+		return aheadStyle.Render(fmt.Sprintf("↑%d", ahead)) + " " +
+			behindStyle.Render(fmt.Sprintf("↓%d", behind))
+	}
+	s := metaStyle.Render("default ") + counts(wt.DefaultAhead, wt.DefaultBehind)
+	if wt.Status.Upstream != "" {
+		s += metaStyle.Render("  ·  "+wt.Status.Upstream+" ") + counts(wt.Status.Ahead, wt.Status.Behind)
+	} else {
+		s += metaStyle.Render("  ·  no upstream")
+	}
+	if wt.Prunable {
+		s += metaStyle.Render("  ·  ") + localStyle.Render("prunable: "+wt.PruneReason)
+	}
+	if wt.Locked {
+		lock := "locked"
+		if wt.LockReason != "" {
+			lock += ": " + strings.ReplaceAll(wt.LockReason, "\n", " ")
+		}
+		s += metaStyle.Render("  ·  ") + behindStyle.Render(lock)
+	}
+	return s
+}
+
 func (m Model) renderNav(width int) string {
+	sync := m.renderSync()
 	if len(m.commits) == 0 {
-		return navStyle.Width(width).Render("")
+		return navStyle.Width(width).Render(sync)
 	}
 
 	var left, right string
@@ -119,118 +280,244 @@ func (m Model) renderNav(width int) string {
 
 	counter := metaStyle.Render(fmt.Sprintf("%d / %d", m.Cursor+1, len(m.commits)))
 	nav := left + " " + counter + " " + right
+	if sync != "" {
+		nav += metaStyle.Render("    ") + sync
+	}
 
 	return navStyle.Width(width).Render(nav)
 }
 
-func (m Model) View(width, height int) string {
-	contentWidth := width - 2 // padding only (no side border)
-	if contentWidth < 0 {
-		contentWidth = 0
-	}
+// bodyHeight is the number of rows each pane has below its title, out of
+// the panel's height: the top border, nav line and pane titles take the
+// rest.
+func (m Model) bodyHeight(height int) int {
+	nav := m.renderNav(max(m.width-2, 0))
+	return max(height-1-lipgloss.Height(nav)-1, 0)
+}
 
-	// Top border consumes 1 line
-	innerHeight := height - 1
-	if innerHeight < 0 {
-		innerHeight = 0
+// paneWidths splits the content width between the commit list and the
+// details, leaving a column for the separator.
+func (m Model) paneWidths(contentWidth int) (list, detail int) {
+	list = contentWidth * 2 / 5
+	if list > 60 {
+		list = 60
 	}
-
-	nav := m.renderNav(contentWidth)
-	navHeight := lipgloss.Height(nav)
-
-	bodyHeight := innerHeight - navHeight
-	if bodyHeight < 0 {
-		bodyHeight = 0
+	detail = contentWidth - list - 3
+	if detail < 0 {
+		detail = 0
 	}
+	return list, detail
+}
 
+// renderList draws one row per commit: pushed marker, short hash, subject
+// and a robot when an AI agent co-authored it. The cursor row is kept in
+// view.
+func (m Model) renderList(width, rows int) []string {
+	start := 0
+	if m.Cursor >= rows {
+		start = m.Cursor - rows + 1
+	}
+	end := min(start+rows, len(m.commits))
 	var lines []string
+	for i := start; i < end; i++ {
+		c := m.commits[i]
+		bg := lipgloss.NewStyle()
+		if i == m.Cursor {
+			bg = rowSelectedStyle
+		}
+		marker := localStyle.Inherit(bg).Render("●")
+		if c.Pushed {
+			marker = pushedStyle.Inherit(bg).Render("⬆")
+		}
+		agent := ""
+		if len(c.AIAgents) > 0 {
+			agent = bg.Render(" ") + agentStyle.Inherit(bg).Render("🤖")
+		}
+		subject := []rune(c.Subject)
+		if limit := width - 13 - lipgloss.Width(agent); limit > 0 && len(subject) > limit {
+			subject = append(subject[:limit-1], '…')
+		}
+		hash := c.Hash
+		if len(hash) > 7 {
+			hash = hash[:7]
+		}
+		row := marker + bg.Render(" ") + hashStyle.Inherit(bg).Render(hash) + bg.Render(" ") +
+			bodyStyle.Inherit(bg).Render(string(subject)) + agent
+		lines = append(lines, bg.Width(width).MaxWidth(width).Render(row))
+	}
+	return lines
+}
 
-	if len(m.commits) == 0 {
-		lines = append(lines, "")
-		lines = append(lines, emptyStyle.Render("No commits ahead of default branch"))
+// detailLines renders the details of the commit under the cursor, wrapped
+// to width and split into lines so they can be scrolled. filesAt is the
+// line of the first entry of the Files list.
+func (m Model) detailLines(width int) (lines []string, filesAt int) {
+	c, ok := m.SelectedCommit()
+	if !ok {
+		return nil, 0
+	}
+	// Status (pushed / local)
+	if c.Pushed {
+		lines = append(lines, pushedStyle.Render("⬆ pushed"))
 	} else {
-		c := m.commits[m.Cursor]
+		lines = append(lines, localStyle.Render("● local only"))
+	}
 
-		// Status (pushed / local)
-		lines = append(lines, "")
-		if c.Pushed {
-			lines = append(lines, pushedStyle.Render("⬆ pushed"))
-		} else {
-			lines = append(lines, localStyle.Render("● local only"))
+	// Tags
+	if len(c.Tags) > 0 {
+		var tagParts []string
+		for _, t := range c.Tags {
+			tagParts = append(tagParts, tagStyle.Render("🏷 "+t))
 		}
+		lines = append(lines, strings.Join(tagParts, " "))
+	}
 
-		// Tags
-		if len(c.Tags) > 0 {
-			var tagParts []string
-			for _, t := range c.Tags {
-				tagParts = append(tagParts, tagStyle.Render("🏷 "+t))
-			}
-			lines = append(lines, strings.Join(tagParts, " "))
+	// AI co-authors
+	if len(c.AIAgents) > 0 {
+		var agentParts []string
+		for _, a := range c.AIAgents {
+			agentParts = append(agentParts, agentStyle.Render("🤖 "+a))
 		}
+		lines = append(lines, strings.Join(agentParts, "  "))
+	}
 
-		// AI co-authors
-		if len(c.AIAgents) > 0 {
-			var agentParts []string
-			for _, a := range c.AIAgents {
-				agentParts = append(agentParts, agentStyle.Render("🤖 "+a))
-			}
-			lines = append(lines, strings.Join(agentParts, "  "))
+	// Commit hash
+	lines = append(lines, "")
+	lines = append(lines, labelStyle.Render("Commit"))
+	lines = append(lines, hashStyle.Render(c.Hash))
+
+	// Subject
+	lines = append(lines, "")
+	lines = append(lines, labelStyle.Render("Subject"))
+	lines = append(lines, lipgloss.NewStyle().Width(width).Render(subjectStyle.Render(c.Subject)))
+
+	// Author + Date
+	lines = append(lines, "")
+	lines = append(lines, labelStyle.Render("Author"))
+	lines = append(lines, metaStyle.Render(c.Author+" · "+c.Date))
+
+	// People named in trailers
+	for _, t := range []struct {
+		label  string
+		values []string
+	}{
+		{"Co-authors", c.CoAuthors},
+		{"Signed-off-by", c.Trailers.Get("Signed-off-by")},
+		{"Reviewed-by", c.Trailers.Get("Reviewed-by")},
+	} {
+		if len(t.values) == 0 {
+			continue
 		}
+		lines = append(lines, "")
+		lines = append(lines, labelStyle.Render(t.label))
+		for _, v := range t.values {
+			lines = append(lines, metaStyle.Render(v))
+		}
+	}
 
-		// Commit hash
+	// Issue references
+	if len(c.Issues) > 0 {
 		lines = append(lines, "")
-		lines = append(lines, labelStyle.Render("Commit"))
-		lines = append(lines, hashStyle.Render(c.Hash))
+		lines = append(lines, labelStyle.Render("Issues"))
+		lines = append(lines, lipgloss.NewStyle().Width(width).Render(issueStyle.Render(strings.Join(c.Issues, "  "))))
+	}
 
-		// Subject
+	// Diff stat
+	if c.Additions > 0 || c.Deletions > 0 {
 		lines = append(lines, "")
-		lines = append(lines, labelStyle.Render("Subject"))
-		wrapped := lipgloss.NewStyle().Width(contentWidth).Render(subjectStyle.Render(c.Subject))
-		lines = append(lines, wrapped)
+		lines = append(lines, labelStyle.Render("Changes"))
+		stat := addStyle.Render(fmt.Sprintf("+%d", c.Additions)) +
+			metaStyle.Render(" / ") +
+			delStyle.Render(fmt.Sprintf("-%d", c.Deletions)) +
+			metaStyle.Render(fmt.Sprintf(" in %d file(s)", len(c.Files)))
+		lines = append(lines, stat)
+	}
 
-		// Author + Date
+	// Body
+	if c.Body != "" {
 		lines = append(lines, "")
-		lines = append(lines, labelStyle.Render("Author"))
-		lines = append(lines, metaStyle.Render(c.Author+" · "+c.Date))
-
-		// Diff stat
-		if c.Additions > 0 || c.Deletions > 0 {
-			lines = append(lines, "")
-			lines = append(lines, labelStyle.Render("Changes"))
-			stat := addStyle.Render(fmt.Sprintf("+%d", c.Additions)) +
-				metaStyle.Render(" / ") +
-				delStyle.Render(fmt.Sprintf("-%d", c.Deletions)) +
-				metaStyle.Render(fmt.Sprintf(" in %d file(s)", len(c.Files)))
-			lines = append(lines, stat)
-		}
-
-		// Body
-		if c.Body != "" {
-			lines = append(lines, "")
-			lines = append(lines, labelStyle.Render("Message"))
-			bodyWrapped := lipgloss.NewStyle().Width(contentWidth).Render(bodyStyle.Render(c.Body))
-			lines = append(lines, bodyWrapped)
-		}
-
-		// Files
-		if len(c.Files) > 0 {
-			lines = append(lines, "")
-			lines = append(lines, labelStyle.Render("Files"))
-			for _, f := range c.Files {
+		lines = append(lines, labelStyle.Render("Message"))
+		lines = append(lines, lipgloss.NewStyle().Width(width).Render(bodyStyle.Render(c.Body)))
+	}
+
+	lines = strings.Split(strings.Join(lines, "\n"), "\n")
+
+	// Files
+	if len(c.Files) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, labelStyle.Render("Files"))
+		filesAt = len(lines)
+		for i, f := range c.Files {
+			if i == m.fileCursor {
+				lines = append(lines, rowSelectedStyle.Inherit(fileStyle).Width(width).Render("> "+f))
+			} else {
 				lines = append(lines, fileStyle.Render("  "+f))
 			}
 		}
 	}
 
-	// Pad/truncate body to bodyHeight
-	for len(lines) < bodyHeight {
-		lines = append(lines, "")
+	return lines, filesAt
+}
+
+// fit pads or truncates lines to exactly n entries, each width wide.
+func fit(lines []string, width, n int) []string {
+	out := make([]string, n)
+	cell := lipgloss.NewStyle().Width(width).MaxWidth(width)
+	for i := range out {
+		var l string
+		if i < len(lines) {
+			l = lines[i]
+		}
+		out[i] = cell.Render(l)
 	}
-	if len(lines) > bodyHeight {
-		lines = lines[:bodyHeight]
+	return out
+}
+
+func (m Model) View(width, height int) string {
+	contentWidth := width - 2 // padding only (no side border)
+	if contentWidth < 0 {
+		contentWidth = 0
+	}
+
+	// Top border consumes 1 line
+	innerHeight := height - 1
+	if innerHeight < 0 {
+		innerHeight = 0
 	}
 
-	inner := nav + "\n" + lipgloss.NewStyle().Padding(0, 1).Render(strings.Join(lines, "\n"))
+	nav := m.renderNav(contentWidth)
+	body := m.bodyHeight(height)
+
+	var inner string
+	if len(m.commits) == 0 {
+		inner = nav + "\n" + lipgloss.NewStyle().Padding(0, 1).Render("\n"+emptyStyle.Render("No commits ahead of default branch"))
+	} else {
+		listWidth, detailWidth := m.paneWidths(contentWidth)
+
+		listTitle, detailTitle := paneTitleStyle, paneTitleStyle
+		if m.Focus == FocusList {
+			listTitle = paneFocusStyle
+		} else {
+			detailTitle = paneFocusStyle
+		}
+		details, _ := m.detailLines(detailWidth)
+		scroll := max(0, min(m.detailScroll, len(details)-body))
+		detailHeader := "Details"
+		if len(details) > body {
+			detailHeader += fmt.Sprintf(" %d–%d of %d", scroll+1, min(scroll+body, len(details)), len(details))
+		}
+
+		left := fit(append([]string{listTitle.Render(fmt.Sprintf("Commits (%d)", len(m.commits)))},
+			m.renderList(listWidth, body)...), listWidth, body+1)
+		right := fit(append([]string{detailTitle.Render(detailHeader)},
+			details[scroll:]...), detailWidth, body+1)
+
+		rows := make([]string, body+1)
+		for i := range rows {
+			rows[i] = left[i] + separatorStyle.Render(" │ ") + right[i]
+		}
+		inner = nav + "\n" + lipgloss.NewStyle().Padding(0, 1).Render(strings.Join(rows, "\n"))
+	}
 
 	// Final pad/truncate to exactly innerHeight
 	allLines := strings.Split(inner, "\n")
@@ -0,0 +1,426 @@
+package stashes
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/marcellolins/mossy/internal/git"
+)
+
+type StashesFetchedMsg struct {
+	Stashes []git.Stash
+	Err     error
+}
+
+func FetchStashes(client *git.Client, wtPath string) tea.Cmd {
+	return func() tea.Msg {
+		stashes, err := client.ListStashes(wtPath)
+		return StashesFetchedMsg{Stashes: stashes, Err: err}
+	}
+}
+
+type StashDiffFetchedMsg struct {
+	Hash string
+	Diff string
+	Err  error
+}
+
+func fetchDiff(client *git.Client, wtPath string, s git.Stash) tea.Cmd {
+	return func() tea.Msg {
+		diff, err := client.StashDiff(wtPath, s.Ref)
+		return StashDiffFetchedMsg{Hash: s.Hash, Diff: diff, Err: err}
+	}
+}
+
+// StashActionMsg asks for Stash to be applied, popped or dropped, or for
+// a new one to be created from the worktree's changes with Message.
+type StashActionMsg struct {
+	Action  string
+	Stash   git.Stash
+	Message string
+}
+
+type StashesClosedMsg struct{}
+
+const (
+	ActionApply = "apply"
+	ActionPop   = "pop"
+	ActionDrop  = "drop"
+	ActionPush  = "push"
+)
+
+const listRows = 8
+
+var (
+	titleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#8FBC8F")).
+			Padding(0, 1)
+
+	cursorStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Background(lipgloss.Color("236")).
+			Bold(true)
+
+	refStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFBD2E"))
+
+	branchStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#8FBC8F"))
+
+	otherBranchStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("245"))
+
+	messageStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("252"))
+
+	dateStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240"))
+
+	dividerStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("238"))
+
+	addStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("2"))
+
+	delStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("1"))
+
+	hunkStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("6"))
+
+	metaStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("245"))
+
+	statusStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFBD2E")).
+			Bold(true).
+			Padding(0, 1)
+
+	helpStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240")).
+			Padding(0, 2)
+
+	errStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF5555")).
+			Padding(0, 1)
+)
+
+type Model struct {
+	client  *git.Client
+	wtPath  string
+	branch  string
+	all     []git.Stash
+	stashes []git.Stash
+	// onlyBranch hides stashes made on other branches.
+	onlyBranch bool
+	cursor     int
+	diff       string
+	diffHash   string
+	scroll     int
+	// confirmDrop is set after the first d; a second d drops the stash.
+	confirmDrop bool
+	creating    bool
+	input       textinput.Model
+	err         error
+	width       int
+	height      int
+	// Busy is set while a git command started from this view is running.
+	Busy string
+}
+
+func New(client *git.Client, wtPath, branch string, width, height int) Model {
+	ti := textinput.New()
+	ti.Placeholder = "stash message (optional)"
+	ti.CharLimit = 128
+	ti.Width = 50
+	return Model{client: client, wtPath: wtPath, branch: branch, input: ti, width: width, height: height}
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// WtPath returns the worktree the stash actions apply to.
+func (m Model) WtPath() string {
+	return m.wtPath
+}
+
+// SetError shows a failure from the last action without leaving the view.
+func (m *Model) SetError(err error) {
+	m.err = err
+	m.Busy = ""
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) selected() (git.Stash, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.stashes) {
+		return git.Stash{}, false
+	}
+	return m.stashes[m.cursor], true
+}
+
+// filter applies the branch filter and returns the command that loads the
+// diff of the stash now under the cursor.
+func (m *Model) filter() tea.Cmd {
+	m.stashes = nil
+	for _, s := range m.all {
+		if !m.onlyBranch || s.Branch == m.branch {
+			m.stashes = append(m.stashes, s)
+		}
+	}
+	if m.cursor >= len(m.stashes) {
+		m.cursor = len(m.stashes) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	return m.loadDiff()
+}
+
+func (m *Model) loadDiff() tea.Cmd {
+	s, ok := m.selected()
+	if !ok {
+		m.diff, m.diffHash = "", ""
+		return nil
+	}
+	if s.Hash == m.diffHash {
+		return nil
+	}
+	m.diff, m.diffHash, m.scroll = "", s.Hash, 0
+	return fetchDiff(m.client, m.wtPath, s)
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case StashesFetchedMsg:
+		m.Busy = ""
+		if msg.Err != nil {
+			m.err = msg.Err
+		}
+		m.all = msg.Stashes
+		m.diffHash = ""
+		return m, m.filter()
+	case StashDiffFetchedMsg:
+		if msg.Hash != m.diffHash {
+			return m, nil
+		}
+		m.diff = msg.Diff
+		if msg.Err != nil {
+			m.diff = "Error: " + msg.Err.Error()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.Busy != "" {
+			return m, nil
+		}
+		if m.creating {
+			return m.updateInput(msg)
+		}
+		confirm := m.confirmDrop
+		m.confirmDrop = false
+		m.err = nil
+		switch msg.String() {
+		case "esc", "q":
+			return m, func() tea.Msg { return StashesClosedMsg{} }
+		case "j", "down":
+			if m.cursor < len(m.stashes)-1 {
+				m.cursor++
+			}
+			return m, m.loadDiff()
+		case "k", "up":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, m.loadDiff()
+		case "J", "ctrl+d":
+			m.scroll += 5
+		case "K", "ctrl+u":
+			m.scroll -= 5
+			if m.scroll < 0 {
+				m.scroll = 0
+			}
+		case "f":
+			m.onlyBranch = !m.onlyBranch
+			return m, m.filter()
+		case "n":
+			m.creating = true
+			m.input.SetValue("")
+			return m, m.input.Focus()
+		case "a", "p":
+			if s, ok := m.selected(); ok {
+				action, busy := ActionApply, "Applying "
+				if msg.String() == "p" {
+					action, busy = ActionPop, "Popping "
+				}
+				m.Busy = busy + s.Ref + "…"
+				return m, func() tea.Msg { return StashActionMsg{Action: action, Stash: s} }
+			}
+		case "d":
+			if s, ok := m.selected(); ok {
+				if !confirm {
+					m.confirmDrop = true
+					return m, nil
+				}
+				m.Busy = "Dropping " + s.Ref + "…"
+				return m, func() tea.Msg { return StashActionMsg{Action: ActionDrop, Stash: s} }
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m Model) updateInput(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.creating = false
+		m.input.Blur()
+		return m, nil
+	case "enter":
+		m.creating = false
+		m.input.Blur()
+		m.Busy = "Stashing changes…"
+		message := strings.TrimSpace(m.input.Value())
+		return m, func() tea.Msg { return StashActionMsg{Action: ActionPush, Message: message} }
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m Model) renderList(rows int) []string {
+	var lines []string
+	if len(m.stashes) == 0 {
+		empty := "No stashes"
+		if m.onlyBranch {
+			empty = "No stashes on " + m.branch
+		}
+		return append(lines, metaStyle.Render("  "+empty))
+	}
+	start := 0
+	if m.cursor >= rows {
+		start = m.cursor - rows + 1
+	}
+	end := start + rows
+	if end > len(m.stashes) {
+		end = len(m.stashes)
+	}
+	for i := start; i < end; i++ {
+		s := m.stashes[i]
+		bStyle := otherBranchStyle
+		if s.Branch == m.branch {
+			bStyle = branchStyle
+		}
+		line := refStyle.Render(fmt.Sprintf("%-10s", s.Ref)) + " " +
+			bStyle.Render(s.Branch) + " " +
+			messageStyle.Render(s.Message) + " " +
+			dateStyle.Render(s.Date)
+		if i == m.cursor {
+			line = cursorStyle.Render("> ") + line
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// renderDiff colours the visible window of the stash's patch.
+func (m Model) renderDiff(rows int) []string {
+	if m.diff == "" {
+		if _, ok := m.selected(); ok {
+			return []string{metaStyle.Render("  Loading diff…")}
+		}
+		return nil
+	}
+	all := strings.Split(strings.TrimRight(m.diff, "\n"), "\n")
+	start := m.scroll
+	if start > len(all)-1 {
+		start = len(all) - 1
+	}
+	end := start + rows
+	if end > len(all) {
+		end = len(all)
+	}
+	var lines []string
+	for _, l := range all[start:end] {
+		l = strings.ReplaceAll(l, "\t", "    ")
+		if r := []rune(l); len(r) > m.width-2 && m.width > 3 {
+			l = string(r[:m.width-3]) + "…"
+		}
+		switch {
+		case strings.HasPrefix(l, "+++"), strings.HasPrefix(l, "---"), strings.HasPrefix(l, "diff "):
+			l = metaStyle.Render(l)
+		case strings.HasPrefix(l, "+"):
+			l = addStyle.Render(l)
+		case strings.HasPrefix(l, "-"):
+			l = delStyle.Render(l)
+		case strings.HasPrefix(l, "@@"):
+			l = hunkStyle.Render(l)
+		}
+		lines = append(lines, " "+l)
+	}
+	return lines
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+
+	scope := "all branches"
+	if m.onlyBranch {
+		scope = m.branch
+	}
+	title := fmt.Sprintf("Stashes — %s (%s)", filepath.Base(m.wtPath), scope)
+	b.WriteString(titleStyle.Render(title))
+	b.WriteString("\n\n")
+
+	listHeight := listRows
+	for _, l := range m.renderList(listRows) {
+		b.WriteString(l)
+		b.WriteString("\n")
+		listHeight--
+	}
+	for ; listHeight > 0; listHeight-- {
+		b.WriteString("\n")
+	}
+	b.WriteString(dividerStyle.Render(strings.Repeat("─", max(m.width, 0))))
+	b.WriteString("\n")
+
+	diffRows := m.height - listRows - 7
+	if diffRows < 1 {
+		diffRows = 1
+	}
+	diff := m.renderDiff(diffRows)
+	for _, l := range diff {
+		b.WriteString(l)
+		b.WriteString("\n")
+	}
+	for i := len(diff); i < diffRows; i++ {
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	switch {
+	case m.creating:
+		b.WriteString(statusStyle.Render("New stash: ") + m.input.View())
+	case m.Busy != "":
+		b.WriteString(statusStyle.Render("⟳ " + m.Busy))
+	case m.confirmDrop:
+		s, _ := m.selected()
+		b.WriteString(statusStyle.Render("Press d again to drop " + s.Ref))
+	case m.err != nil:
+		b.WriteString(errStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+	}
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("a: apply • p: pop • d: drop • n: new stash • f: this branch only • J/K: scroll diff • esc: close"))
+
+	return b.String()
+}
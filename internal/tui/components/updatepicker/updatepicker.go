@@ -0,0 +1,126 @@
+package updatepicker
+
+import (
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/marcellolins/mossy/internal/git"
+)
+
+type UpdateRequestMsg struct {
+	WtPath   string
+	Strategy git.UpdateStrategy
+}
+
+type UpdateCancelledMsg struct{}
+
+const modalWidth = 50
+
+var strategyHelp = map[git.UpdateStrategy]string{
+	git.UpdateRebase:      "Replay commits on top of the default branch; needs a clean tree",
+	git.UpdateAutostash:   "Stash local changes, rebase, then restore them",
+	git.UpdateMerge:       "Merge the default branch in with a merge commit",
+	git.UpdateFastForward: "Only move the branch if it has no commits of its own",
+}
+
+var (
+	titleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#8FBC8F")).
+			Padding(0, 1)
+
+	valueStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("245")).
+			Padding(0, 1)
+
+	choiceActiveStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FFBD2E")).
+				Bold(true).
+				Padding(0, 1)
+
+	choiceInactiveStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("245")).
+				Padding(0, 1)
+
+	helpStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240")).
+			Padding(0, 1)
+
+	modalStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("240")).
+			Padding(1, 2).
+			Width(modalWidth)
+)
+
+type Model struct {
+	wtPath string
+	cursor int
+	width  int
+	height int
+}
+
+// New opens the picker for wtPath with the given strategy preselected.
+func New(wtPath string, preselect git.UpdateStrategy, width, height int) Model {
+	m := Model{wtPath: wtPath, width: width, height: height}
+	for i, s := range git.UpdateStrategies {
+		if s == preselect {
+			m.cursor = i
+		}
+	}
+	return m
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q":
+			return m, func() tea.Msg { return UpdateCancelledMsg{} }
+		case "j", "down", "tab":
+			m.cursor = (m.cursor + 1) % len(git.UpdateStrategies)
+		case "k", "up", "shift+tab":
+			m.cursor = (m.cursor + len(git.UpdateStrategies) - 1) % len(git.UpdateStrategies)
+		case "enter", "u":
+			req := UpdateRequestMsg{WtPath: m.wtPath, Strategy: git.UpdateStrategies[m.cursor]}
+			return m, func() tea.Msg { return req }
+		}
+	}
+	return m, nil
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render("Update " + filepath.Base(m.wtPath)))
+	b.WriteString("\n")
+	b.WriteString(valueStyle.Render("Bring the branch up to date with the default branch"))
+	b.WriteString("\n\n")
+
+	for i, s := range git.UpdateStrategies {
+		if i == m.cursor {
+			b.WriteString(choiceActiveStyle.Render("◉ " + s.Describe()))
+		} else {
+			b.WriteString(choiceInactiveStyle.Render("○ " + s.Describe()))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(valueStyle.Render(strategyHelp[git.UpdateStrategies[m.cursor]]))
+	b.WriteString("\n\n")
+	b.WriteString(helpStyle.Render("enter: update • esc: cancel"))
+
+	modal := modalStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
@@ -1,21 +1,56 @@
 package worktreecreate
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/marcellolins/mossy/internal/git"
 )
 
 type WorktreeCreateRequestMsg struct {
 	Name   string
 	Branch string
+	Mode   git.AddMode
+	Base   git.Base
 }
 
 type WorktreeCreateCancelledMsg struct{}
 
-const modalWidth = 50
+type BranchesFetchedMsg struct {
+	Branches []git.Branch
+	Err      error
+}
+
+func FetchBranches(client *git.Client, repoPath string) tea.Cmd {
+	return func() tea.Msg {
+		branches, err := client.ListBranches(repoPath)
+		return BranchesFetchedMsg{Branches: branches, Err: err}
+	}
+}
+
+const (
+	modalWidth    = 50
+	maxBranchRows = 6
+)
+
+const (
+	focusMode = iota
+	focusName
+	focusBranch
+	focusBase
+	focusBaseRef
+	focusCreate
+	focusCancel
+	focusCount
+)
+
+var (
+	modeLabels = []string{"New", "Local", "Remote"}
+	baseLabels = []string{"Default", "Remote default", "Ref"}
+)
 
 var (
 	titleStyle = lipgloss.NewStyle().
@@ -37,6 +72,29 @@ var (
 				Foreground(lipgloss.Color("245")).
 				Padding(0, 2)
 
+	modeActiveStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFBD2E")).
+			Bold(true).
+			Padding(0, 1)
+
+	modeInactiveStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("245")).
+				Padding(0, 1)
+
+	branchItemStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("252")).
+			Padding(0, 1)
+
+	branchSelectedStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FFFFFF")).
+				Background(lipgloss.Color("236")).
+				Bold(true).
+				Padding(0, 1)
+
+	hintStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240")).
+			Padding(0, 1)
+
 	modalStyle = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
 			BorderForeground(lipgloss.Color("240")).
@@ -45,12 +103,19 @@ var (
 )
 
 type Model struct {
-	nameInput   textinput.Model
-	branchInput textinput.Model
-	focus       int
-	width       int
-	height      int
-	Creating    bool
+	nameInput    textinput.Model
+	branchInput  textinput.Model
+	baseInput    textinput.Model
+	mode         git.AddMode
+	baseKind     git.BaseKind
+	branches     []git.Branch
+	branchErr    error
+	filtered     []git.Branch
+	branchCursor int
+	focus        int
+	width        int
+	height       int
+	Creating     bool
 }
 
 func New(width, height int) Model {
@@ -65,10 +130,16 @@ func New(width, height int) Model {
 	bi.CharLimit = 128
 	bi.Width = modalWidth - 8
 
+	ri := textinput.New()
+	ri.Placeholder = "branch, tag or commit"
+	ri.CharLimit = 128
+	ri.Width = modalWidth - 8
+
 	return Model{
 		nameInput:   ni,
 		branchInput: bi,
-		focus:       0,
+		baseInput:   ri,
+		focus:       focusName,
 		width:       width,
 		height:      height,
 	}
@@ -82,12 +153,112 @@ func (m *Model) SetSize(width, height int) {
 func (m *Model) updateFocus() {
 	m.nameInput.Blur()
 	m.branchInput.Blur()
+	m.baseInput.Blur()
 
 	switch m.focus {
-	case 0:
+	case focusName:
 		m.nameInput.Focus()
-	case 1:
+	case focusBranch:
 		m.branchInput.Focus()
+	case focusBaseRef:
+		m.baseInput.Focus()
+	}
+}
+
+// focusable reports whether a field is shown for the current mode: the
+// base only applies to new branches, and its ref input only to BaseRef.
+func (m Model) focusable(f int) bool {
+	switch f {
+	case focusBase:
+		return m.mode == git.AddNewBranch
+	case focusBaseRef:
+		return m.mode == git.AddNewBranch && m.baseKind == git.BaseRef
+	}
+	return true
+}
+
+// moveFocus steps through the visible fields, wrapping when wrap is set
+// and stopping at either end otherwise.
+func (m *Model) moveFocus(delta int, wrap bool) {
+	f := m.focus
+	for i := 0; i < focusCount; i++ {
+		f += delta
+		if f < 0 || f >= focusCount {
+			if !wrap {
+				return
+			}
+			f = (f + focusCount) % focusCount
+		}
+		if m.focusable(f) {
+			m.focus = f
+			m.updateFocus()
+			return
+		}
+	}
+}
+
+// setMode switches between creating a new branch and picking an existing
+// one, swapping the branch field between free text and a filter.
+func (m *Model) setMode(mode git.AddMode) {
+	m.mode = mode
+	if mode == git.AddNewBranch {
+		m.branchInput.Placeholder = "feature/my-feature"
+	} else {
+		m.branchInput.Placeholder = "type to filter…"
+	}
+	m.filterBranches()
+}
+
+// filterBranches narrows the branch list to the current mode and the text
+// typed into the branch field. Local branches that are already checked out
+// are left out since git refuses to check them out twice.
+func (m *Model) filterBranches() {
+	m.filtered = nil
+	m.branchCursor = 0
+	if m.mode == git.AddNewBranch {
+		return
+	}
+	query := strings.ToLower(m.branchInput.Value())
+	for _, b := range m.branches {
+		if b.Remote != (m.mode == git.AddRemoteBranch) {
+			continue
+		}
+		if !b.Remote && b.WorktreePath != "" {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(b.Name), query) {
+			continue
+		}
+		m.filtered = append(m.filtered, b)
+	}
+}
+
+// selectedBranch returns the branch the request should use: the typed name
+// for new branches, or the highlighted list entry otherwise.
+func (m Model) selectedBranch() (git.Branch, bool) {
+	if m.mode == git.AddNewBranch {
+		name := m.branchInput.Value()
+		return git.Branch{Name: name}, name != ""
+	}
+	if m.branchCursor < 0 || m.branchCursor >= len(m.filtered) {
+		return git.Branch{}, false
+	}
+	return m.filtered[m.branchCursor], true
+}
+
+func (m Model) submit() (Model, tea.Cmd) {
+	branch, ok := m.selectedBranch()
+	if !ok {
+		return m, nil
+	}
+	name := m.nameInput.Value()
+	if name == "" && m.mode != git.AddNewBranch {
+		name = strings.ReplaceAll(branch.LocalName(), "/", "-")
+	}
+	mode := m.mode
+	base := git.Base{Kind: m.baseKind, Ref: m.baseInput.Value()}
+	return m, func() tea.Msg {
+		return WorktreeCreateRequestMsg{Name: name, Branch: branch.Name, Mode: mode, Base: base}
 	}
 }
 
@@ -100,57 +271,74 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		return m, nil
 	}
 	switch msg := msg.(type) {
+	case BranchesFetchedMsg:
+		m.branches = msg.Branches
+		m.branchErr = msg.Err
+		m.filterBranches()
+		return m, nil
 	case tea.KeyMsg:
+		listActive := m.focus == focusBranch && m.mode != git.AddNewBranch
 		switch msg.String() {
 		case "esc":
 			return m, func() tea.Msg { return WorktreeCreateCancelledMsg{} }
 		case "tab":
-			m.focus = (m.focus + 1) % 4
-			m.updateFocus()
+			m.moveFocus(1, true)
 			return m, nil
 		case "shift+tab":
-			m.focus = (m.focus + 3) % 4
-			m.updateFocus()
+			m.moveFocus(-1, true)
 			return m, nil
 		case "up":
-			if m.focus > 0 {
-				if m.focus == 3 {
-					m.focus = 2
-				} else {
-					m.focus--
-				}
-				m.updateFocus()
+			if listActive && m.branchCursor > 0 {
+				m.branchCursor--
+				return m, nil
+			}
+			if m.focus == focusCancel {
+				m.focus = focusCreate
+			} else {
+				m.moveFocus(-1, false)
 			}
 			return m, nil
 		case "down":
-			if m.focus < 2 {
-				m.focus++
-				m.updateFocus()
+			if listActive && m.branchCursor < len(m.filtered)-1 {
+				m.branchCursor++
+				return m, nil
+			}
+			if m.focus < focusCreate {
+				m.moveFocus(1, false)
 			}
 			return m, nil
 		case "left":
-			if m.focus == 3 {
-				m.focus = 2
+			switch m.focus {
+			case focusMode:
+				m.setMode((m.mode + 2) % 3)
+				return m, nil
+			case focusBase:
+				m.baseKind = (m.baseKind + 2) % 3
+				return m, nil
+			case focusCancel:
+				m.focus = focusCreate
 				return m, nil
 			}
 		case "right":
-			if m.focus == 2 {
-				m.focus = 3
+			switch m.focus {
+			case focusMode:
+				m.setMode((m.mode + 1) % 3)
+				return m, nil
+			case focusBase:
+				m.baseKind = (m.baseKind + 1) % 3
+				return m, nil
+			case focusCreate:
+				m.focus = focusCancel
 				return m, nil
 			}
 		case "enter":
 			switch m.focus {
-			case 0:
-				m.focus = 1
-				m.updateFocus()
+			case focusMode, focusName, focusBase:
+				m.moveFocus(1, false)
 				return m, nil
-			case 1, 2:
-				name := m.nameInput.Value()
-				branch := m.branchInput.Value()
-				return m, func() tea.Msg {
-					return WorktreeCreateRequestMsg{Name: name, Branch: branch}
-				}
-			case 3:
+			case focusBranch, focusBaseRef, focusCreate:
+				return m.submit()
+			case focusCancel:
 				return m, func() tea.Msg { return WorktreeCreateCancelledMsg{} }
 			}
 		}
@@ -158,14 +346,76 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 	var cmd tea.Cmd
 	switch m.focus {
-	case 0:
+	case focusName:
 		m.nameInput, cmd = m.nameInput.Update(msg)
-	case 1:
+	case focusBranch:
+		before := m.branchInput.Value()
 		m.branchInput, cmd = m.branchInput.Update(msg)
+		if m.branchInput.Value() != before {
+			m.filterBranches()
+		}
+	case focusBaseRef:
+		m.baseInput, cmd = m.baseInput.Update(msg)
 	}
 	return m, cmd
 }
 
+// renderChoice draws a row of radio options with the selected one marked.
+func renderChoice(labels []string, selected int, focused bool) string {
+	var parts []string
+	for i, label := range labels {
+		if i == selected {
+			if focused {
+				parts = append(parts, modeActiveStyle.Render("◉ "+label))
+			} else {
+				parts = append(parts, labelStyle.UnsetBold().Render("◉ "+label))
+			}
+		} else {
+			parts = append(parts, modeInactiveStyle.Render("○ "+label))
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// renderBranchList shows a window of the filtered branches that keeps the
+// cursor visible.
+func (m Model) renderBranchList() string {
+	if m.branchErr != nil {
+		return hintStyle.Render("Error listing branches: " + m.branchErr.Error())
+	}
+	if m.branches == nil {
+		return hintStyle.Render("Loading branches…")
+	}
+	if len(m.filtered) == 0 {
+		return hintStyle.Render("No matching branches")
+	}
+	start := 0
+	if m.branchCursor >= maxBranchRows {
+		start = m.branchCursor - maxBranchRows + 1
+	}
+	end := start + maxBranchRows
+	if end > len(m.filtered) {
+		end = len(m.filtered)
+	}
+	var rows []string
+	for i := start; i < end; i++ {
+		b := m.filtered[i]
+		label := b.Name
+		if b.Upstream != "" {
+			label += " → " + b.Upstream
+		}
+		if i == m.branchCursor {
+			rows = append(rows, branchSelectedStyle.Render("> "+label))
+		} else {
+			rows = append(rows, branchItemStyle.Render("  "+label))
+		}
+	}
+	if len(m.filtered) > maxBranchRows {
+		rows = append(rows, hintStyle.Render(fmt.Sprintf("  %d/%d", m.branchCursor+1, len(m.filtered))))
+	}
+	return strings.Join(rows, "\n")
+}
+
 func (m Model) View() string {
 	var b strings.Builder
 
@@ -183,6 +433,11 @@ func (m Model) View() string {
 		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
 	}
 
+	b.WriteString(labelStyle.Render("Source"))
+	b.WriteString("\n")
+	b.WriteString(renderChoice(modeLabels, int(m.mode), m.focus == focusMode))
+	b.WriteString("\n\n")
+
 	b.WriteString(labelStyle.Render("Worktree name"))
 	b.WriteString("\n")
 	b.WriteString(lipgloss.NewStyle().Padding(0, 1).Render(m.nameInput.View()))
@@ -192,15 +447,28 @@ func (m Model) View() string {
 	b.WriteString("\n")
 	b.WriteString(lipgloss.NewStyle().Padding(0, 1).Render(m.branchInput.View()))
 	b.WriteString("\n\n")
+	if m.mode != git.AddNewBranch {
+		b.WriteString(m.renderBranchList())
+		b.WriteString("\n\n")
+	} else {
+		b.WriteString(labelStyle.Render("Base"))
+		b.WriteString("\n")
+		b.WriteString(renderChoice(baseLabels, int(m.baseKind), m.focus == focusBase))
+		b.WriteString("\n\n")
+		if m.baseKind == git.BaseRef {
+			b.WriteString(lipgloss.NewStyle().Padding(0, 1).Render(m.baseInput.View()))
+			b.WriteString("\n\n")
+		}
+	}
 
 	createBtn := "[Create]"
 	cancelBtn := "[Cancel]"
-	if m.focus == 2 {
+	if m.focus == focusCreate {
 		createBtn = activeButtonStyle.Render(createBtn)
 	} else {
 		createBtn = inactiveButtonStyle.Render(createBtn)
 	}
-	if m.focus == 3 {
+	if m.focus == focusCancel {
 		cancelBtn = activeButtonStyle.Render(cancelBtn)
 	} else {
 		cancelBtn = inactiveButtonStyle.Render(cancelBtn)
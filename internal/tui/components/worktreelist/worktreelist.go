@@ -17,9 +17,9 @@ type WorktreesFetchedMsg struct {
 	Err       error
 }
 
-func FetchWorktrees(repoPath string) tea.Cmd {
+func FetchWorktrees(client *git.Client, repoPath string) tea.Cmd {
 	return func() tea.Msg {
-		wts, err := git.ListWorktrees(repoPath)
+		wts, err := client.ListWorktrees(repoPath)
 		return WorktreesFetchedMsg{Worktrees: wts, Err: err}
 	}
 }
@@ -58,6 +58,42 @@ var (
 
 	rebasingStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("214"))
+
+	stagedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("2"))
+
+	unstagedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("214"))
+
+	untrackedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("245"))
+
+	conflictStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("1")).
+			Bold(true)
+
+	aheadStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#8FBC8F"))
+
+	behindStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFBD2E"))
+
+	operationStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF5555")).
+			Bold(true)
+
+	lockedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFBD2E"))
+
+	prunableStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240"))
+
+	mainNameStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#8FBC8F")).
+			Bold(true)
+
+	agentStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#B48EAD"))
 )
 
 var (
@@ -90,6 +126,11 @@ func (m Model) SelectedWorktree() (git.Worktree, bool) {
 	return m.worktrees[m.cursor], true
 }
 
+// Worktrees returns the listed worktrees.
+func (m Model) Worktrees() []git.Worktree {
+	return m.worktrees
+}
+
 func (m Model) HasWorktrees() bool {
 	return m.loaded && len(m.worktrees) > 0
 }
@@ -166,12 +207,22 @@ func (m Model) View(width, height int) string {
 
 	var b strings.Builder
 
-	// Column layout: name(grow) + lines(fixed) + commit(fixed) + branch(fixed)
+	// Column layout: name(grow) + lines(fixed) + ai(optional) + sync(optional)
+	// + status(optional) + state(optional) + commit(fixed) + branch(fixed)
 	const (
-		linesWidth  = 16
-		commitWidth = 9
-		padWidth    = 4 // outer padding from rowStyle (2 each side)
+		linesWidth   = 16
+		commitWidth  = 9
+		padWidth     = 4 // outer padding from rowStyle (2 each side)
+		minNameWidth = 12
 	)
+	aiWidth, syncWidth, statusWidth, stateWidth := 0, 16, 16, 12
+	// The AI column only shows when some branch has AI commits.
+	for _, wt := range m.worktrees {
+		if wt.AI.AI.Commits > 0 {
+			aiWidth = 11
+			break
+		}
+	}
 	// Branch column gets ~35% of total width, capped at 40
 	branchColWidth := (width - padWidth) * 7 / 20
 	if branchColWidth > 40 {
@@ -180,15 +231,29 @@ func (m Model) View(width, height int) string {
 	if branchColWidth < 10 {
 		branchColWidth = 10
 	}
-	nameWidth := width - padWidth - linesWidth - commitWidth - branchColWidth
+	spare := func() int {
+		return width - padWidth - linesWidth - aiWidth - syncWidth - statusWidth - stateWidth - commitWidth - branchColWidth
+	}
+	// Drop optional columns, least useful first, until the name fits.
+	for _, w := range []*int{&aiWidth, &syncWidth, &stateWidth, &statusWidth} {
+		if spare() >= minNameWidth {
+			break
+		}
+		*w = 0
+	}
+	nameWidth := max(spare(), minNameWidth)
 
 	cellStyle := lipgloss.NewStyle()
 
 	colHeader := rowStyle.Render(
-		columnHeaderStyle.Width(nameWidth).MaxWidth(nameWidth).Render("\uf413 Worktree") +
-			columnHeaderStyle.Width(linesWidth).MaxWidth(linesWidth).Render("\uf457") +
-			columnHeaderStyle.Width(commitWidth).MaxWidth(commitWidth).Render("Commit") +
-			columnHeaderStyle.Width(branchColWidth).MaxWidth(branchColWidth).Render("\uf418 Branch"))
+		cell(columnHeaderStyle, nameWidth, "\uf413 Worktree") +
+			cell(columnHeaderStyle, linesWidth, "\uf457") +
+			cell(columnHeaderStyle, aiWidth, "AI") +
+			cell(columnHeaderStyle, syncWidth, "Sync") +
+			cell(columnHeaderStyle, statusWidth, "Status") +
+			cell(columnHeaderStyle, stateWidth, "State") +
+			cell(columnHeaderStyle, commitWidth, "Commit") +
+			cell(columnHeaderStyle, branchColWidth, "\uf418 Branch"))
 	b.WriteString(colHeader)
 	b.WriteString("\n")
 
@@ -199,6 +264,9 @@ func (m Model) View(width, height int) string {
 		bg := lipgloss.Color("236")
 
 		nStyle := nameStyle
+		if wt.Main {
+			nStyle = mainNameStyle
+		}
 		hStyle := hashStyle
 		bStyle := branchStyle
 		aStyle := addStyle
@@ -222,16 +290,21 @@ func (m Model) View(width, height int) string {
 			if selected {
 				rbStyle = rbStyle.Background(bg)
 			}
-			linesCell = rbStyle.Render(m.spinner.View() + " rebasing…")
+			linesCell = rbStyle.Render(m.spinner.View() + " updating…")
 		} else if wt.Additions > 0 || wt.Deletions > 0 {
 			linesCell = aStyle.Render(fmt.Sprintf("+%d", wt.Additions)) +
 				cStyle.Render(" ") +
 				dStyle.Render(fmt.Sprintf("-%d", wt.Deletions))
 		}
-		line := cStyle.Width(nameWidth).MaxWidth(nameWidth).Render(nStyle.Render(wtName)) +
-			cStyle.Width(linesWidth).MaxWidth(linesWidth).Render(linesCell) +
-			cStyle.Width(commitWidth).MaxWidth(commitWidth).Render(hStyle.Render(wt.HEAD[:7])) +
-			cStyle.Width(branchColWidth).MaxWidth(branchColWidth).Render(bStyle.Render(wt.Branch))
+		statusCell, stateCell := renderStatus(wt.Status, cStyle)
+		line := cell(cStyle, nameWidth, renderMarkers(wt, cStyle)+nStyle.Render(wtName)) +
+			cell(cStyle, linesWidth, linesCell) +
+			cell(cStyle, aiWidth, renderAI(wt.AI, cStyle)) +
+			cell(cStyle, syncWidth, renderSync(wt, cStyle)) +
+			cell(cStyle, statusWidth, statusCell) +
+			cell(cStyle, stateWidth, stateCell) +
+			cell(cStyle, commitWidth, hStyle.Render(wt.HEAD[:7])) +
+			cell(cStyle, branchColWidth, bStyle.Render(wt.Branch))
 
 		b.WriteString(rStyle.Render(line))
 		b.WriteString("\n")
@@ -251,3 +324,79 @@ func (m Model) View(width, height int) string {
 	}
 	return strings.Join(lines, "\n")
 }
+
+// cell renders content in a column of the given width. A dropped column
+// has width 0 and renders nothing.
+func cell(style lipgloss.Style, width int, content string) string {
+	if width == 0 {
+		return ""
+	}
+	return style.Width(width).MaxWidth(width).Render(content)
+}
+
+// renderStatus returns the dirty-state counts (staged ●, unstaged ✚,
+// untracked ?, conflicted ✖) and the in-progress operation marker. base
+// carries the row background so selected rows stay highlighted.
+func renderStatus(st git.Status, base lipgloss.Style) (counts, state string) {
+	bg := base.GetBackground()
+	var parts []string
+	add := func(style lipgloss.Style, symbol string, n int) {
+		if n > 0 {
+			parts = append(parts, style.Background(bg).Render(fmt.Sprintf("%s%d", symbol, n)))
+		}
+	}
+	add(conflictStyle, "✖", st.Conflicted)
+	add(stagedStyle, "●", st.Staged)
+	add(unstagedStyle, "✚", st.Unstaged)
+	add(untrackedStyle, "?", st.Untracked)
+	counts = strings.Join(parts, base.Render(" "))
+
+	if st.Operation != "" {
+		state = operationStyle.Background(bg).Render(strings.ToUpper(st.Operation))
+	}
+	return counts, state
+}
+
+// renderAI shows the share of the branch's own commits, then of their
+// added and removed lines, that were made with AI agents.
+func renderAI(s git.AIStats, base lipgloss.Style) string {
+	if s.AI.Commits == 0 {
+		return ""
+	}
+	return agentStyle.Background(base.GetBackground()).
+		Render(fmt.Sprintf("%d%%/%d%%", s.CommitShare(s.AI), s.LineShare(s.AI)))
+}
+
+// renderMarkers prefixes the name of the main worktree with a house, locked
+// worktrees with a lock icon and prunable ones with a trash can.
+func renderMarkers(wt git.Worktree, base lipgloss.Style) string {
+	bg := base.GetBackground()
+	var s string
+	if wt.Main {
+		s += mainNameStyle.Background(bg).Render("\uf015") + base.Render(" ")
+	}
+	if wt.Locked {
+		s += lockedStyle.Background(bg).Render("\uf023") + base.Render(" ")
+	}
+	if wt.Prunable {
+		s += prunableStyle.Background(bg).Render("\uf1f8") + base.Render(" ")
+	}
+	return s
+}
+
+// renderSync shows ↑ahead ↓behind relative to the default branch, followed
+// by ⇡ahead ⇣behind relative to the upstream when the branch tracks one.
+func renderSync(wt git.Worktree, base lipgloss.Style) string {
+	bg := base.GetBackground()
+	var parts []string
+	add := func(style lipgloss.Style, symbol string, n int) {
+		if n > 0 {
+			parts = append(parts, style.Background(bg).Render(fmt.Sprintf("%s%d", symbol, n)))
+		}
+	}
+	add(aheadStyle, "↑", wt.DefaultAhead)
+	add(behindStyle, "↓", wt.DefaultBehind)
+	add(aheadStyle, "⇡", wt.Status.Ahead)
+	add(behindStyle, "⇣", wt.Status.Behind)
+	return strings.Join(parts, base.Render(" "))
+}
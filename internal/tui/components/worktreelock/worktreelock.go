@@ -0,0 +1,105 @@
+package worktreelock
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type WorktreeLockRequestMsg struct {
+	WtPath string
+	Reason string
+}
+
+type WorktreeLockCancelledMsg struct{}
+
+const modalWidth = 50
+
+var (
+	titleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#8FBC8F")).
+			Padding(0, 1)
+
+	labelStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Bold(true).
+			Padding(0, 1)
+
+	valueStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("245")).
+			Padding(0, 1)
+
+	helpStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240")).
+			Padding(0, 1)
+
+	modalStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("240")).
+			Padding(1, 2).
+			Width(modalWidth)
+)
+
+type Model struct {
+	wtPath      string
+	reasonInput textinput.Model
+	width       int
+	height      int
+}
+
+func New(wtPath string, width, height int) Model {
+	ri := textinput.New()
+	ri.Placeholder = "optional, e.g. on external drive"
+	ri.Focus()
+	ri.CharLimit = 128
+	ri.Width = modalWidth - 8
+	return Model{wtPath: wtPath, reasonInput: ri, width: width, height: height}
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m Model) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc":
+			return m, func() tea.Msg { return WorktreeLockCancelledMsg{} }
+		case "enter":
+			req := WorktreeLockRequestMsg{
+				WtPath: m.wtPath,
+				Reason: strings.TrimSpace(m.reasonInput.Value()),
+			}
+			return m, func() tea.Msg { return req }
+		}
+	}
+	var cmd tea.Cmd
+	m.reasonInput, cmd = m.reasonInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render("Lock Worktree"))
+	b.WriteString("\n\n")
+	b.WriteString(valueStyle.Render(filepath.Base(m.wtPath) + " will be protected from prune and remove."))
+	b.WriteString("\n\n")
+	b.WriteString(labelStyle.Render("Reason"))
+	b.WriteString("\n")
+	b.WriteString(lipgloss.NewStyle().Padding(0, 1).Render(m.reasonInput.View()))
+	b.WriteString("\n\n")
+	b.WriteString(helpStyle.Render("enter: lock • esc: cancel"))
+
+	modal := modalStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
@@ -0,0 +1,331 @@
+package worktreemerge
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/marcellolins/mossy/internal/git"
+)
+
+type WorktreeMergeRequestMsg struct {
+	WtPath      string
+	Branch      string
+	Strategy    git.MergeStrategy
+	RemoveAfter bool
+}
+
+type WorktreeMergeCancelledMsg struct{}
+
+type MergePreviewFetchedMsg struct {
+	DefaultBranch string
+	Commits       []git.Commit
+	Err           error
+}
+
+func FetchPreview(client *git.Client, repoPath, branch string) tea.Cmd {
+	return func() tea.Msg {
+		commits, err := client.ListCommits(repoPath, branch)
+		return MergePreviewFetchedMsg{
+			DefaultBranch: client.DefaultBranch(repoPath),
+			Commits:       commits,
+			Err:           err,
+		}
+	}
+}
+
+const (
+	modalWidth    = 60
+	maxCommitRows = 8
+)
+
+const (
+	focusStrategy = iota
+	focusRemove
+	focusMerge
+	focusCancel
+	focusCount
+)
+
+var strategyLabels = []string{"Fast-forward", "Merge commit", "Squash"}
+
+var (
+	titleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#8FBC8F")).
+			Padding(0, 1)
+
+	labelStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Bold(true).
+			Padding(0, 1)
+
+	valueStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("245")).
+			Padding(0, 1)
+
+	hashStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFBD2E"))
+
+	subjectStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("252"))
+
+	activeButtonStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FFBD2E")).
+				Bold(true).
+				Padding(0, 2)
+
+	inactiveButtonStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("245")).
+				Padding(0, 2)
+
+	choiceActiveStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FFBD2E")).
+				Bold(true).
+				Padding(0, 1)
+
+	choiceSelectedStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FFFFFF")).
+				Padding(0, 1)
+
+	choiceInactiveStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("245")).
+				Padding(0, 1)
+
+	modalStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("240")).
+			Padding(1, 2).
+			Width(modalWidth)
+
+	checkboxActiveStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FFBD2E")).
+				Bold(true).
+				Padding(0, 1)
+
+	checkboxInactiveStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("245")).
+				Padding(0, 1)
+)
+
+type Model struct {
+	wtPath        string
+	branch        string
+	defaultBranch string
+	commits       []git.Commit
+	loaded        bool
+	err           error
+	strategy      git.MergeStrategy
+	removeAfter   bool
+	focus         int
+	width         int
+	height        int
+	Merging       bool
+}
+
+func New(wtPath, branch string, width, height int) Model {
+	return Model{
+		wtPath: wtPath,
+		branch: branch,
+		focus:  focusStrategy,
+		width:  width,
+		height: height,
+	}
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if m.Merging {
+		return m, nil
+	}
+	switch msg := msg.(type) {
+	case MergePreviewFetchedMsg:
+		m.defaultBranch = msg.DefaultBranch
+		m.commits = msg.Commits
+		m.err = msg.Err
+		m.loaded = true
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			return m, func() tea.Msg { return WorktreeMergeCancelledMsg{} }
+		case "tab":
+			m.focus = (m.focus + 1) % focusCount
+			return m, nil
+		case "shift+tab":
+			m.focus = (m.focus + focusCount - 1) % focusCount
+			return m, nil
+		case "up":
+			switch m.focus {
+			case focusCancel:
+				m.focus = focusMerge
+			case focusRemove, focusMerge:
+				m.focus--
+			}
+			return m, nil
+		case "down":
+			if m.focus < focusMerge {
+				m.focus++
+			}
+			return m, nil
+		case "left":
+			switch m.focus {
+			case focusStrategy:
+				m.strategy = (m.strategy + 2) % 3
+				return m, nil
+			case focusCancel:
+				m.focus = focusMerge
+				return m, nil
+			}
+		case "right":
+			switch m.focus {
+			case focusStrategy:
+				m.strategy = (m.strategy + 1) % 3
+				return m, nil
+			case focusMerge:
+				m.focus = focusCancel
+				return m, nil
+			}
+		case " ":
+			if m.focus == focusRemove {
+				m.removeAfter = !m.removeAfter
+				return m, nil
+			}
+		case "enter":
+			switch m.focus {
+			case focusStrategy:
+				m.focus = focusRemove
+				return m, nil
+			case focusRemove:
+				m.removeAfter = !m.removeAfter
+				return m, nil
+			case focusMerge:
+				if !m.loaded || m.err != nil || len(m.commits) == 0 {
+					return m, nil
+				}
+				req := WorktreeMergeRequestMsg{
+					WtPath:      m.wtPath,
+					Branch:      m.branch,
+					Strategy:    m.strategy,
+					RemoveAfter: m.removeAfter,
+				}
+				return m, func() tea.Msg { return req }
+			case focusCancel:
+				return m, func() tea.Msg { return WorktreeMergeCancelledMsg{} }
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m Model) renderCommits() string {
+	if !m.loaded {
+		return valueStyle.Render("Loading commits…")
+	}
+	if m.err != nil {
+		return valueStyle.Render(fmt.Sprintf("Error listing commits: %v", m.err))
+	}
+	if len(m.commits) == 0 {
+		return valueStyle.Render("Nothing to merge — no commits ahead of " + m.defaultBranch)
+	}
+	var rows []string
+	for i, c := range m.commits {
+		if i == maxCommitRows {
+			rows = append(rows, valueStyle.Render(fmt.Sprintf("… and %d more", len(m.commits)-maxCommitRows)))
+			break
+		}
+		subject := []rune(c.Subject)
+		if limit := modalWidth - 18; len(subject) > limit {
+			subject = append(subject[:limit-1], '…')
+		}
+		rows = append(rows, lipgloss.NewStyle().Padding(0, 1).Render(
+			hashStyle.Render(c.Hash[:7])+" "+subjectStyle.Render(string(subject))))
+	}
+	return strings.Join(rows, "\n")
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render("Merge Worktree"))
+	b.WriteString("\n\n")
+
+	if m.Merging {
+		mergingStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFBD2E")).
+			Bold(true).
+			Padding(0, 1)
+		b.WriteString(mergingStyle.Render("⟳ Merging…"))
+
+		modal := modalStyle.Render(b.String())
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+	}
+
+	target := m.defaultBranch
+	if target == "" {
+		target = "default branch"
+	}
+	b.WriteString(labelStyle.Render("Branch"))
+	b.WriteString("\n")
+	b.WriteString(valueStyle.Render(m.branch + " → " + target))
+	b.WriteString("\n\n")
+
+	b.WriteString(labelStyle.Render(fmt.Sprintf("Commits (%d)", len(m.commits))))
+	b.WriteString("\n")
+	b.WriteString(m.renderCommits())
+	b.WriteString("\n\n")
+
+	b.WriteString(labelStyle.Render("Strategy"))
+	b.WriteString("\n")
+	var choices []string
+	for i, label := range strategyLabels {
+		switch {
+		case git.MergeStrategy(i) == m.strategy && m.focus == focusStrategy:
+			choices = append(choices, choiceActiveStyle.Render("◉ "+label))
+		case git.MergeStrategy(i) == m.strategy:
+			choices = append(choices, choiceSelectedStyle.Render("◉ "+label))
+		default:
+			choices = append(choices, choiceInactiveStyle.Render("○ "+label))
+		}
+	}
+	b.WriteString(strings.Join(choices, ""))
+	b.WriteString("\n\n")
+
+	check := "[ ]"
+	if m.removeAfter {
+		check = "[✓]"
+	}
+	toggleLabel := check + " Remove worktree and branch afterwards"
+	if m.focus == focusRemove {
+		b.WriteString(checkboxActiveStyle.Render(toggleLabel))
+	} else {
+		b.WriteString(checkboxInactiveStyle.Render(toggleLabel))
+	}
+	b.WriteString("\n\n")
+
+	mergeBtn := "[Merge]"
+	cancelBtn := "[Cancel]"
+	if m.focus == focusMerge {
+		mergeBtn = activeButtonStyle.Render(mergeBtn)
+	} else {
+		mergeBtn = inactiveButtonStyle.Render(mergeBtn)
+	}
+	if m.focus == focusCancel {
+		cancelBtn = activeButtonStyle.Render(cancelBtn)
+	} else {
+		cancelBtn = inactiveButtonStyle.Render(cancelBtn)
+	}
+	b.WriteString(mergeBtn + cancelBtn)
+
+	modal := modalStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
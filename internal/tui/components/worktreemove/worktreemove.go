@@ -0,0 +1,293 @@
+package worktreemove
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// WorktreeMoveRequestMsg asks for the worktree to be moved to Path, whose
+// last element is Name. NewBranch equals Branch when the branch should
+// keep its name.
+type WorktreeMoveRequestMsg struct {
+	WtPath    string
+	Path      string
+	Name      string
+	Branch    string
+	NewBranch string
+}
+
+type WorktreeMoveCancelledMsg struct{}
+
+const modalWidth = 50
+
+const (
+	focusName = iota
+	focusBranch
+	focusMove
+	focusCancel
+	focusCount
+)
+
+var (
+	titleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#8FBC8F")).
+			Padding(0, 1)
+
+	labelStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Bold(true).
+			Padding(0, 1)
+
+	hintStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("240")).
+			Padding(0, 1)
+
+	errStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF5555")).
+			Padding(0, 1)
+
+	activeButtonStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FFBD2E")).
+				Bold(true).
+				Padding(0, 2)
+
+	inactiveButtonStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("245")).
+				Padding(0, 2)
+
+	modalStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("240")).
+			Padding(1, 2).
+			Width(modalWidth)
+)
+
+type Model struct {
+	wtPath      string
+	branch      string
+	nameInput   textinput.Model
+	branchInput textinput.Model
+	focus       int
+	width       int
+	height      int
+	Moving      bool
+}
+
+func New(wtPath, branch string, width, height int) Model {
+	ni := textinput.New()
+	ni.SetValue(filepath.Base(wtPath))
+	ni.Focus()
+	ni.CharLimit = 128
+	ni.Width = modalWidth - 8
+
+	bi := textinput.New()
+	bi.SetValue(branch)
+	bi.CharLimit = 128
+	bi.Width = modalWidth - 8
+
+	return Model{
+		wtPath:      wtPath,
+		branch:      branch,
+		nameInput:   ni,
+		branchInput: bi,
+		focus:       focusName,
+		width:       width,
+		height:      height,
+	}
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// hasBranch reports whether the worktree is on a branch that can be
+// renamed.
+func (m Model) hasBranch() bool {
+	return m.branch != "" && m.branch != "(detached)"
+}
+
+func (m *Model) setFocus(f int) {
+	if f == focusBranch && !m.hasBranch() {
+		if f > m.focus {
+			f = focusMove
+		} else {
+			f = focusName
+		}
+	}
+	m.focus = f
+	m.nameInput.Blur()
+	m.branchInput.Blur()
+	switch f {
+	case focusName:
+		m.nameInput.Focus()
+	case focusBranch:
+		m.branchInput.Focus()
+	}
+}
+
+var errRelativePath = errors.New("use a plain name, or an absolute or ~/ path to move elsewhere")
+
+// destination is where the worktree ends up. A plain name renames it in
+// place, so a worktree that lives outside the worktree_path template stays
+// where it is; moving it elsewhere takes an absolute or ~/ path. Relative
+// paths are refused rather than resolved against a directory the user
+// cannot see.
+func (m Model) destination() (string, error) {
+	value := strings.TrimSpace(m.nameInput.Value())
+	switch {
+	case value == "":
+		return "", nil
+	case strings.HasPrefix(value, "~/"):
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(home, value[2:]), nil
+	case filepath.IsAbs(value):
+		return filepath.Clean(value), nil
+	case strings.ContainsRune(value, '/') || strings.ContainsRune(value, filepath.Separator) ||
+		value == "." || value == "..":
+		return "", errRelativePath
+	}
+	return filepath.Join(filepath.Dir(m.wtPath), value), nil
+}
+
+func (m Model) submit() (Model, tea.Cmd) {
+	dest, err := m.destination()
+	if dest == "" || err != nil {
+		return m, nil
+	}
+	name := filepath.Base(dest)
+	newBranch := m.branch
+	if m.hasBranch() {
+		if b := strings.TrimSpace(m.branchInput.Value()); b != "" {
+			newBranch = b
+		}
+	}
+	req := WorktreeMoveRequestMsg{WtPath: m.wtPath, Path: dest, Name: name, Branch: m.branch, NewBranch: newBranch}
+	return m, func() tea.Msg { return req }
+}
+
+func (m Model) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if m.Moving {
+		return m, nil
+	}
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc":
+			return m, func() tea.Msg { return WorktreeMoveCancelledMsg{} }
+		case "tab":
+			m.setFocus((m.focus + 1) % focusCount)
+			return m, nil
+		case "shift+tab":
+			m.setFocus((m.focus + focusCount - 1) % focusCount)
+			return m, nil
+		case "up":
+			if m.focus == focusCancel {
+				m.setFocus(focusMove)
+			} else if m.focus > focusName {
+				m.setFocus(m.focus - 1)
+			}
+			return m, nil
+		case "down":
+			if m.focus < focusMove {
+				m.setFocus(m.focus + 1)
+			}
+			return m, nil
+		case "left":
+			if m.focus == focusCancel {
+				m.setFocus(focusMove)
+				return m, nil
+			}
+		case "right":
+			if m.focus == focusMove {
+				m.setFocus(focusCancel)
+				return m, nil
+			}
+		case "enter":
+			if m.focus == focusCancel {
+				return m, func() tea.Msg { return WorktreeMoveCancelledMsg{} }
+			}
+			return m.submit()
+		}
+	}
+
+	var cmd tea.Cmd
+	switch m.focus {
+	case focusName:
+		m.nameInput, cmd = m.nameInput.Update(msg)
+	case focusBranch:
+		m.branchInput, cmd = m.branchInput.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render("Move Worktree"))
+	b.WriteString("\n\n")
+
+	if m.Moving {
+		movingStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFBD2E")).
+			Bold(true).
+			Padding(0, 1)
+		b.WriteString(movingStyle.Render("⟳ Moving worktree…"))
+
+		modal := modalStyle.Render(b.String())
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+	}
+
+	b.WriteString(labelStyle.Render("Worktree name or path"))
+	b.WriteString("\n")
+	b.WriteString(lipgloss.NewStyle().Padding(0, 1).Render(m.nameInput.View()))
+	b.WriteString("\n")
+	switch dest, err := m.destination(); {
+	case err != nil:
+		b.WriteString(errStyle.Width(modalWidth - 4).Render(err.Error()))
+	case dest == m.wtPath:
+		b.WriteString(hintStyle.Render("Stays in " + dest))
+	case dest != "":
+		b.WriteString(hintStyle.Width(modalWidth - 4).Render("Moves to " + dest))
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString(labelStyle.Render("Branch name"))
+	b.WriteString("\n")
+	if m.hasBranch() {
+		b.WriteString(lipgloss.NewStyle().Padding(0, 1).Render(m.branchInput.View()))
+	} else {
+		b.WriteString(hintStyle.Render("detached HEAD — nothing to rename"))
+	}
+	b.WriteString("\n\n")
+
+	moveBtn := "[Move]"
+	cancelBtn := "[Cancel]"
+	if m.focus == focusMove {
+		moveBtn = activeButtonStyle.Render(moveBtn)
+	} else {
+		moveBtn = inactiveButtonStyle.Render(moveBtn)
+	}
+	if m.focus == focusCancel {
+		cancelBtn = activeButtonStyle.Render(cancelBtn)
+	} else {
+		cancelBtn = inactiveButtonStyle.Render(cancelBtn)
+	}
+	b.WriteString(moveBtn + cancelBtn)
+
+	modal := modalStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
@@ -0,0 +1,222 @@
+package worktreepush
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/marcellolins/mossy/internal/git"
+)
+
+type WorktreePushRequestMsg struct {
+	WtPath         string
+	Branch         string
+	ForceWithLease bool
+}
+
+type WorktreePushCancelledMsg struct{}
+
+const modalWidth = 50
+
+const (
+	focusForce = iota
+	focusPush
+	focusCancel
+	focusCount
+)
+
+var (
+	titleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#8FBC8F")).
+			Padding(0, 1)
+
+	labelStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Bold(true).
+			Padding(0, 1)
+
+	valueStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("245")).
+			Padding(0, 1)
+
+	warnStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFBD2E")).
+			Padding(0, 1)
+
+	activeButtonStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FFBD2E")).
+				Bold(true).
+				Padding(0, 2)
+
+	inactiveButtonStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("245")).
+				Padding(0, 2)
+
+	modalStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("240")).
+			Padding(1, 2).
+			Width(modalWidth)
+
+	checkboxActiveStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FFBD2E")).
+				Bold(true).
+				Padding(0, 1)
+
+	checkboxInactiveStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("245")).
+				Padding(0, 1)
+)
+
+type Model struct {
+	wtPath   string
+	branch   string
+	upstream string
+	// remote receives the branch when it has no upstream yet.
+	remote  string
+	ahead   int
+	behind  int
+	force   bool
+	focus   int
+	width   int
+	height  int
+	Pushing bool
+}
+
+// New opens the push dialog for a worktree. Force with lease starts out
+// checked when the branch has diverged from its upstream, which is what a
+// rebase of already pushed commits leaves behind.
+func New(wt git.Worktree, remote string, width, height int) Model {
+	return Model{
+		wtPath:   wt.Path,
+		branch:   wt.Branch,
+		upstream: wt.Status.Upstream,
+		remote:   remote,
+		ahead:    wt.Status.Ahead,
+		behind:   wt.Status.Behind,
+		force:    wt.Status.Ahead > 0 && wt.Status.Behind > 0,
+		focus:    focusPush,
+		width:    width,
+		height:   height,
+	}
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if m.Pushing {
+		return m, nil
+	}
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			return m, func() tea.Msg { return WorktreePushCancelledMsg{} }
+		case "tab":
+			m.focus = (m.focus + 1) % focusCount
+		case "shift+tab":
+			m.focus = (m.focus + focusCount - 1) % focusCount
+		case "up":
+			if m.focus != focusForce {
+				m.focus = focusForce
+			}
+		case "down":
+			if m.focus == focusForce {
+				m.focus = focusPush
+			}
+		case "left":
+			if m.focus == focusCancel {
+				m.focus = focusPush
+			}
+		case "right":
+			if m.focus == focusPush {
+				m.focus = focusCancel
+			}
+		case " ":
+			if m.focus == focusForce {
+				m.force = !m.force
+			}
+		case "enter":
+			switch m.focus {
+			case focusForce:
+				m.force = !m.force
+			case focusPush:
+				req := WorktreePushRequestMsg{WtPath: m.wtPath, Branch: m.branch, ForceWithLease: m.force}
+				return m, func() tea.Msg { return req }
+			case focusCancel:
+				return m, func() tea.Msg { return WorktreePushCancelledMsg{} }
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m Model) View() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render("Push Worktree"))
+	b.WriteString("\n\n")
+
+	if m.Pushing {
+		pushingStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFBD2E")).
+			Bold(true).
+			Padding(0, 1)
+		b.WriteString(pushingStyle.Render("⟳ Pushing…"))
+
+		modal := modalStyle.Render(b.String())
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+	}
+
+	b.WriteString(labelStyle.Render("Branch"))
+	b.WriteString("\n")
+	if m.upstream == "" {
+		b.WriteString(valueStyle.Render(m.branch + " → " + m.remote + "/" + m.branch + " (new upstream)"))
+	} else {
+		b.WriteString(valueStyle.Render(fmt.Sprintf("%s → %s  ⇡%d ⇣%d", m.branch, m.upstream, m.ahead, m.behind)))
+	}
+	b.WriteString("\n\n")
+
+	if m.behind > 0 {
+		b.WriteString(warnStyle.Render(fmt.Sprintf("The remote has %d commit(s) this branch does not.", m.behind)))
+		b.WriteString("\n\n")
+	}
+
+	check := "[ ]"
+	if m.force {
+		check = "[✓]"
+	}
+	toggleLabel := check + " Force with lease"
+	if m.focus == focusForce {
+		b.WriteString(checkboxActiveStyle.Render(toggleLabel))
+	} else {
+		b.WriteString(checkboxInactiveStyle.Render(toggleLabel))
+	}
+	b.WriteString("\n\n")
+
+	pushBtn := "[Push]"
+	cancelBtn := "[Cancel]"
+	if m.focus == focusPush {
+		pushBtn = activeButtonStyle.Render(pushBtn)
+	} else {
+		pushBtn = inactiveButtonStyle.Render(pushBtn)
+	}
+	if m.focus == focusCancel {
+		cancelBtn = activeButtonStyle.Render(cancelBtn)
+	} else {
+		cancelBtn = inactiveButtonStyle.Render(cancelBtn)
+	}
+	b.WriteString(pushBtn + cancelBtn)
+
+	modal := modalStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
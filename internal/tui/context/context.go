@@ -1,6 +1,11 @@
 package context
 
-import "time"
+import (
+	"time"
+
+	"github.com/marcellolins/mossy/internal/config"
+	"github.com/marcellolins/mossy/internal/git"
+)
 
 type Repository struct {
 	Name          string
@@ -8,7 +13,21 @@ type Repository struct {
 	WorktreeCount int
 }
 
+// FetchState tracks the background fetch of all repositories.
+type FetchState struct {
+	Running bool
+	Total   int
+	Done    int
+	// Progress holds the latest step of each repository still fetching,
+	// keyed by repository path.
+	Progress map[string]git.FetchProgress
+	// Failed maps repository paths to the reason their fetch failed.
+	Failed map[string]string
+}
+
 type ProgramContext struct {
+	Git             *git.Client
+	Config          config.Config
 	Width           int
 	Height          int
 	Repos           []Repository
@@ -22,4 +41,26 @@ type ProgramContext struct {
 	ShowHelp        bool
 	TmuxPanes       map[string]string // worktree path → tmux pane ID
 	TmuxVisiblePane string            // currently joined pane ID
+	Fetch           FetchState
+}
+
+// RepoLabel names the registered repository at path for status messages:
+// its name, or its path when another registered repository has the same
+// name.
+func (c *ProgramContext) RepoLabel(path string) string {
+	name := ""
+	for _, r := range c.Repos {
+		if r.Path == path {
+			name = r.Name
+		}
+	}
+	if name == "" {
+		return path
+	}
+	for _, r := range c.Repos {
+		if r.Name == name && r.Path != path {
+			return path
+		}
+	}
+	return name
 }
@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/marcellolins/mossy/internal/git"
+	"github.com/marcellolins/mossy/internal/tui/context"
+)
+
+// fetchInterval is how often all repositories are fetched in the
+// background while auto-refresh is on.
+const fetchInterval = 5 * time.Minute
+
+// fetchWorkers bounds how many repositories are fetched at once.
+const fetchWorkers = 4
+
+type fetchTickMsg time.Time
+
+// Fetch messages carry the channel they came from, so the next one can be
+// waited for without keeping the channel on the Model.
+type fetchProgressMsg struct {
+	ch       <-chan tea.Msg
+	path     string
+	progress git.FetchProgress
+}
+
+type fetchRepoDoneMsg struct {
+	ch   <-chan tea.Msg
+	path string
+	err  error
+}
+
+type fetchAllDoneMsg struct{}
+
+func fetchTickCmd() tea.Cmd {
+	return tea.Tick(fetchInterval, func(t time.Time) tea.Msg {
+		return fetchTickMsg(t)
+	})
+}
+
+// startFetchAll fetches every registered repository on a bounded pool of
+// workers. Progress and results arrive as messages on the returned channel,
+// which is closed after fetchAllDoneMsg.
+func startFetchAll(client *git.Client, repos []context.Repository) <-chan tea.Msg {
+	ch := make(chan tea.Msg, 64)
+	jobs := make(chan context.Repository)
+	var wg sync.WaitGroup
+	for i := 0; i < fetchWorkers && i < len(repos); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for repo := range jobs {
+				err := client.FetchAll(repo.Path, func(p git.FetchProgress) {
+					// Progress is cosmetic; drop updates rather than stall
+					// git when the UI is behind.
+					select {
+					case ch <- fetchProgressMsg{ch: ch, path: repo.Path, progress: p}:
+					default:
+					}
+				})
+				ch <- fetchRepoDoneMsg{ch: ch, path: repo.Path, err: err}
+			}
+		}()
+	}
+	go func() {
+		for _, r := range repos {
+			jobs <- r
+		}
+		close(jobs)
+		wg.Wait()
+		ch <- fetchAllDoneMsg{}
+		close(ch)
+	}()
+	return ch
+}
+
+// waitForFetch delivers the next message from a running fetch.
+func waitForFetch(ch <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+// fetchAll starts a background fetch unless one is already running.
+func (m Model) fetchAll() tea.Cmd {
+	if m.ctx.Fetch.Running || len(m.ctx.Repos) == 0 {
+		return nil
+	}
+	repos := append([]context.Repository(nil), m.ctx.Repos...)
+	m.ctx.Fetch = context.FetchState{
+		Running:  true,
+		Total:    len(repos),
+		Progress: make(map[string]git.FetchProgress),
+		Failed:   make(map[string]string),
+	}
+	return waitForFetch(startFetchAll(m.ctx.Git, repos))
+}
+
+// fetchFailures summarises the repositories whose fetch failed.
+func (m Model) fetchFailures(failed map[string]string) string {
+	if len(failed) == 1 {
+		for path, reason := range failed {
+			return fmt.Sprintf("%s: %s", m.ctx.RepoLabel(path), reason)
+		}
+	}
+	names := make([]string, 0, len(failed))
+	for path := range failed {
+		names = append(names, m.ctx.RepoLabel(path))
+	}
+	sort.Strings(names)
+	return fmt.Sprintf("Fetch failed for %d repositories: %s", len(names), strings.Join(names, ", "))
+}
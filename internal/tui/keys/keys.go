@@ -12,9 +12,22 @@ type KeyMap struct {
 	NewWorktree    key.Binding
 	RemoveWorktree key.Binding
 	UpdateWorktree key.Binding
+	MergeWorktree  key.Binding
+	PushWorktree   key.Binding
+	Conflicts      key.Binding
+	MoveWorktree   key.Binding
+	Stashes        key.Binding
+	LockWorktree   key.Binding
+	Prune          key.Binding
 	PrevCommit     key.Binding
 	NextCommit     key.Binding
+	CommitDiff     key.Binding
+	PanelFocus     key.Binding
+	PanelScroll    key.Binding
+	WorkingDiff    key.Binding
+	Graph          key.Binding
 	Refresh        key.Binding
+	FetchAll       key.Binding
 	AutoRefresh    key.Binding
 	TmuxPane       key.Binding
 	Help           key.Binding
@@ -58,6 +71,34 @@ var Keys = KeyMap{
 		key.WithKeys("u"),
 		key.WithHelp("u", "update from default branch"),
 	),
+	MergeWorktree: key.NewBinding(
+		key.WithKeys("m"),
+		key.WithHelp("m", "merge into default branch"),
+	),
+	PushWorktree: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "push branch"),
+	),
+	Conflicts: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "resolve rebase or merge conflicts"),
+	),
+	MoveWorktree: key.NewBinding(
+		key.WithKeys("M"),
+		key.WithHelp("M", "move/rename worktree"),
+	),
+	Stashes: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "manage stashes"),
+	),
+	LockWorktree: key.NewBinding(
+		key.WithKeys("L"),
+		key.WithHelp("L", "lock/unlock worktree"),
+	),
+	Prune: key.NewBinding(
+		key.WithKeys("P"),
+		key.WithHelp("P", "prune stale worktrees"),
+	),
 	PrevCommit: key.NewBinding(
 		key.WithKeys("["),
 		key.WithHelp("[", "prev commit"),
@@ -66,10 +107,34 @@ var Keys = KeyMap{
 		key.WithKeys("]"),
 		key.WithHelp("]", "next commit"),
 	),
+	PanelFocus: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "focus commits/details"),
+	),
+	PanelScroll: key.NewBinding(
+		key.WithKeys("J", "K"),
+		key.WithHelp("J/K", "scroll focused pane / files"),
+	),
+	CommitDiff: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "show commit diff"),
+	),
+	WorkingDiff: key.NewBinding(
+		key.WithKeys("D"),
+		key.WithHelp("D", "show uncommitted changes"),
+	),
+	Graph: key.NewBinding(
+		key.WithKeys("g"),
+		key.WithHelp("g", "commit graph"),
+	),
 	Refresh: key.NewBinding(
 		key.WithKeys("r"),
 		key.WithHelp("r", "refresh"),
 	),
+	FetchAll: key.NewBinding(
+		key.WithKeys("f"),
+		key.WithHelp("f", "fetch all repos"),
+	),
 	AutoRefresh: key.NewBinding(
 		key.WithKeys("R"),
 		key.WithHelp("R", "toggle auto-refresh"),
@@ -95,8 +160,8 @@ func (k KeyMap) ShortHelp() []key.Binding {
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Left, k.Right},
-		{k.AddRepo, k.DeleteRepo, k.NewWorktree, k.RemoveWorktree, k.UpdateWorktree},
-		{k.PrevCommit, k.NextCommit, k.Refresh, k.AutoRefresh},
+		{k.AddRepo, k.DeleteRepo, k.NewWorktree, k.RemoveWorktree, k.UpdateWorktree, k.MergeWorktree, k.PushWorktree, k.Conflicts, k.MoveWorktree, k.Stashes, k.LockWorktree, k.Prune},
+		{k.PrevCommit, k.NextCommit, k.PanelFocus, k.PanelScroll, k.CommitDiff, k.WorkingDiff, k.Graph, k.Refresh, k.FetchAll, k.AutoRefresh},
 		{k.TmuxPane, k.Help, k.Quit},
 	}
 }
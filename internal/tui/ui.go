@@ -1,9 +1,12 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
@@ -13,19 +16,29 @@ import (
 	"github.com/marcellolins/mossy/internal/config"
 	"github.com/marcellolins/mossy/internal/git"
 	"github.com/marcellolins/mossy/internal/tmux"
+	"github.com/marcellolins/mossy/internal/tui/components/aisummary"
+	"github.com/marcellolins/mossy/internal/tui/components/conflicts"
+	"github.com/marcellolins/mossy/internal/tui/components/diffview"
 	"github.com/marcellolins/mossy/internal/tui/components/footer"
+	"github.com/marcellolins/mossy/internal/tui/components/graph"
 	"github.com/marcellolins/mossy/internal/tui/components/repopicker"
 	"github.com/marcellolins/mossy/internal/tui/components/sidepanel"
+	"github.com/marcellolins/mossy/internal/tui/components/stashes"
 	"github.com/marcellolins/mossy/internal/tui/components/tabs"
+	"github.com/marcellolins/mossy/internal/tui/components/updatepicker"
 	"github.com/marcellolins/mossy/internal/tui/components/worktreecreate"
 	"github.com/marcellolins/mossy/internal/tui/components/worktreelist"
+	"github.com/marcellolins/mossy/internal/tui/components/worktreelock"
+	"github.com/marcellolins/mossy/internal/tui/components/worktreemerge"
+	"github.com/marcellolins/mossy/internal/tui/components/worktreemove"
+	"github.com/marcellolins/mossy/internal/tui/components/worktreepush"
 	"github.com/marcellolins/mossy/internal/tui/components/worktreeremove"
 	"github.com/marcellolins/mossy/internal/tui/context"
 )
 
 type configLoadedMsg struct {
-	repos []config.Repository
-	err   error
+	cfg config.Config
+	err error
 }
 
 type tickMsg time.Time
@@ -42,6 +55,42 @@ type worktreeRemovedMsg struct {
 	err  error
 }
 
+type worktreeMergedMsg struct {
+	name     string
+	path     string
+	branch   string
+	strategy git.MergeStrategy
+	removed  bool
+	err      error
+}
+
+type worktreeMovedMsg struct {
+	oldPath string
+	newPath string
+	// moved is set once the directory has moved, even if renaming the
+	// branch failed afterwards.
+	moved bool
+	err   error
+}
+
+type worktreePushedMsg struct {
+	branch string
+	force  bool
+	result git.PushResult
+	err    error
+}
+
+type worktreeLockedMsg struct {
+	name   string
+	locked bool
+	err    error
+}
+
+type worktreesPrunedMsg struct {
+	names []string
+	err   error
+}
+
 type repoWorktreeResult struct {
 	path      string
 	worktrees []git.Worktree
@@ -54,10 +103,24 @@ type commitsFetchedMsg struct {
 }
 
 type rebaseFinishedMsg struct {
-	wtPath string
+	wtPath   string
+	strategy git.UpdateStrategy
+	err      error
+}
+
+type rebaseStepMsg struct {
+	action string
 	err    error
 }
 
+type stashStepMsg struct {
+	err error
+}
+
+type editorClosedMsg struct {
+	err error
+}
+
 type allWorktreesFetchedMsg struct {
 	results []repoWorktreeResult
 }
@@ -84,6 +147,16 @@ const (
 	viewConfirmDelete
 	viewCreateWorktree
 	viewRemoveWorktree
+	viewMergeWorktree
+	viewConflicts
+	viewUpdatePicker
+	viewLockWorktree
+	viewMoveWorktree
+	viewStashes
+	viewPushWorktree
+	viewCommitDiff
+	viewWorkingDiff
+	viewGraph
 )
 
 type Model struct {
@@ -93,13 +166,26 @@ type Model struct {
 	repoPicker     repopicker.Model
 	worktreeCreate worktreecreate.Model
 	worktreeRemove worktreeremove.Model
+	worktreeMerge  worktreemerge.Model
+	conflicts      conflicts.Model
+	updatePicker   updatepicker.Model
+	worktreeLock   worktreelock.Model
+	worktreeMove   worktreemove.Model
+	stashes        stashes.Model
+	worktreePush   worktreepush.Model
+	commitDiff     diffview.Model
+	workingDiff    diffview.Model
+	diffWtPath     string // worktree shown in viewWorkingDiff
+	commitGraph    graph.Model
 	worktreeList   worktreelist.Model
+	aiSummary      aisummary.Model
 	sidePanel      sidepanel.Model
 	view           viewState
 }
 
 func New() Model {
 	ctx := &context.ProgramContext{
+		Git:         git.NewClient(),
 		ActiveRepo:  -1,
 		AutoRefresh: true,
 		TmuxPanes:   make(map[string]string),
@@ -109,6 +195,7 @@ func New() Model {
 		tabs:         tabs.New(ctx),
 		footer:       footer.New(ctx),
 		worktreeList: worktreelist.New(ctx),
+		aiSummary:    aisummary.New(),
 		sidePanel:    sidepanel.New(),
 		view:         viewNormal,
 	}
@@ -119,16 +206,41 @@ func (m Model) Init() tea.Cmd {
 		tea.SetWindowTitle("mossy"),
 		func() tea.Msg {
 			cfg, err := config.Load()
-			return configLoadedMsg{repos: cfg.Repos, err: err}
+			return configLoadedMsg{cfg: cfg, err: err}
 		},
 	)
 }
 
+// agentRules merges the configured AI agent rules over the built-in ones.
+func agentRules(cfg *config.AIAgents) git.AgentRules {
+	rules := git.DefaultAgentRules
+	if cfg == nil {
+		return rules
+	}
+	if len(cfg.Names) > 0 {
+		rules.Names = cfg.Names
+	}
+	if len(cfg.Trailers) > 0 {
+		rules.Trailers = cfg.Trailers
+	}
+	if len(cfg.Emails) > 0 {
+		rules.Emails = cfg.Emails
+	}
+	return rules
+}
+
 func (m Model) fetchActiveWorktrees() tea.Cmd {
 	if m.ctx.ActiveRepo < 0 || m.ctx.ActiveRepo >= len(m.ctx.Repos) {
 		return nil
 	}
-	return worktreelist.FetchWorktrees(m.ctx.Repos[m.ctx.ActiveRepo].Path)
+	return worktreelist.FetchWorktrees(m.ctx.Git, m.ctx.Repos[m.ctx.ActiveRepo].Path)
+}
+
+func (m Model) fetchAIStats() tea.Cmd {
+	if m.ctx.ActiveRepo < 0 || m.ctx.ActiveRepo >= len(m.ctx.Repos) {
+		return nil
+	}
+	return aisummary.FetchStats(m.ctx.Git, m.ctx.Repos[m.ctx.ActiveRepo].Path)
 }
 
 func (m Model) fetchCommits() tea.Cmd {
@@ -141,19 +253,31 @@ func (m Model) fetchCommits() tea.Cmd {
 	if branch == "" || branch == "(detached)" {
 		return nil
 	}
+	client := m.ctx.Git
 	return func() tea.Msg {
-		commits, err := git.ListCommits(repoPath, branch)
+		commits, err := client.ListCommits(repoPath, branch)
 		return commitsFetchedMsg{commits: commits, err: err}
 	}
 }
 
+// saveRepos writes the current repository list back to the config file,
+// keeping global settings and per-repository overrides intact.
 func (m Model) saveRepos() tea.Cmd {
+	prev := make(map[string]config.Repository, len(m.ctx.Config.Repos))
+	for _, r := range m.ctx.Config.Repos {
+		prev[r.Path] = r
+	}
 	repos := make([]config.Repository, len(m.ctx.Repos))
 	for i, r := range m.ctx.Repos {
-		repos[i] = config.Repository{Name: r.Name, Path: r.Path}
+		repo := prev[r.Path]
+		repo.Name = r.Name
+		repo.Path = r.Path
+		repos[i] = repo
 	}
+	m.ctx.Config.Repos = repos
+	cfg := m.ctx.Config
 	return func() tea.Msg {
-		_ = config.Save(config.Config{Repos: repos})
+		_ = config.Save(cfg)
 		return nil
 	}
 }
@@ -169,10 +293,11 @@ func (m Model) fetchAllWorktrees() tea.Cmd {
 	if len(refs) == 0 {
 		return nil
 	}
+	client := m.ctx.Git
 	return func() tea.Msg {
 		var results []repoWorktreeResult
 		for _, r := range refs {
-			wts, err := git.ListWorktrees(r.path)
+			wts, err := client.ListWorktrees(r.path)
 			results = append(results, repoWorktreeResult{
 				path:      r.path,
 				worktrees: wts,
@@ -194,6 +319,41 @@ func (m *Model) saveTmuxSessions() {
 	_ = config.SaveSessions(config.Sessions{Panes: m.ctx.TmuxPanes})
 }
 
+// forgetTmuxPane kills and forgets the pane that belonged to a worktree
+// that no longer exists.
+func (m *Model) forgetTmuxPane(wtPath string) {
+	paneID, ok := m.ctx.TmuxPanes[wtPath]
+	if !ok {
+		return
+	}
+	if paneID == m.ctx.TmuxVisiblePane {
+		m.ctx.TmuxVisiblePane = ""
+	}
+	tmux.KillPane(paneID)
+	delete(m.ctx.TmuxPanes, wtPath)
+	m.saveTmuxSessions()
+}
+
+// rekeyTmuxPane keeps a worktree's pane after the worktree has moved. The
+// directory is renamed in place, so the shell inside the pane is already
+// in the right place.
+func (m *Model) rekeyTmuxPane(oldPath, newPath string) {
+	paneID, ok := m.ctx.TmuxPanes[oldPath]
+	if !ok {
+		return
+	}
+	delete(m.ctx.TmuxPanes, oldPath)
+	m.ctx.TmuxPanes[newPath] = paneID
+	m.saveTmuxSessions()
+}
+
+// refuseOnMain explains that action is not available for the main worktree.
+func (m *Model) refuseOnMain(action string) tea.Cmd {
+	m.ctx.Message = fmt.Sprintf("The main worktree cannot be %s", action)
+	m.ctx.MessageExpiry = time.Now().Add(3 * time.Second)
+	return uiTickCmd()
+}
+
 func (m *Model) quitTmux() {
 	m.hideTmuxPane()
 	m.saveTmuxSessions()
@@ -203,15 +363,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case configLoadedMsg:
 		if msg.err == nil {
-			for _, r := range msg.repos {
+			m.ctx.Config = msg.cfg
+			for _, r := range msg.cfg.Repos {
 				m.ctx.Repos = append(m.ctx.Repos, context.Repository{
 					Name: r.Name,
 					Path: r.Path,
 				})
+				m.ctx.Git.SetRemotes(r.Path, git.Remotes{Base: r.BaseRemote, Push: r.PushRemote})
 			}
 			if len(m.ctx.Repos) > 0 {
 				m.ctx.ActiveRepo = 0
 			}
+			if err := m.ctx.Git.SetAgentRules(agentRules(msg.cfg.AIAgents)); err != nil {
+				m.ctx.Message = fmt.Sprintf("Error in ai_agents: %v", err)
+				m.ctx.MessageExpiry = time.Now().Add(5 * time.Second)
+			}
 		}
 		if tmux.InsideTmux() {
 			if sessions, err := config.LoadSessions(); err == nil {
@@ -226,7 +392,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 		m.ctx.LastRefresh = time.Now()
-		return m, tea.Batch(m.fetchActiveWorktrees(), tickCmd(), uiTickCmd())
+		return m, tea.Batch(m.fetchActiveWorktrees(), tickCmd(), uiTickCmd(), m.fetchAll(), fetchTickCmd())
 	case uiTickMsg:
 		if m.ctx.Message != "" && !m.ctx.MessageExpiry.IsZero() && time.Now().After(m.ctx.MessageExpiry) {
 			m.ctx.Message = ""
@@ -236,11 +402,44 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, uiTickCmd()
 		}
 		return m, nil
+	case fetchTickMsg:
+		if !m.ctx.AutoRefresh {
+			return m, fetchTickCmd()
+		}
+		return m, tea.Batch(m.fetchAll(), fetchTickCmd())
+	case fetchProgressMsg:
+		if m.ctx.Fetch.Running {
+			m.ctx.Fetch.Progress[msg.path] = msg.progress
+		}
+		return m, waitForFetch(msg.ch)
+	case fetchRepoDoneMsg:
+		m.ctx.Fetch.Done++
+		delete(m.ctx.Fetch.Progress, msg.path)
+		if msg.err != nil {
+			m.ctx.Fetch.Failed[msg.path] = msg.err.Error()
+		}
+		cmds := []tea.Cmd{waitForFetch(msg.ch)}
+		if m.ctx.ActiveRepo >= 0 && m.ctx.Repos[m.ctx.ActiveRepo].Path == msg.path {
+			cmds = append(cmds, m.fetchActiveWorktrees())
+		}
+		return m, tea.Batch(cmds...)
+	case fetchAllDoneMsg:
+		m.ctx.Fetch.Running = false
+		if len(m.ctx.Fetch.Failed) > 0 {
+			m.ctx.Message = m.fetchFailures(m.ctx.Fetch.Failed)
+			m.ctx.MessageExpiry = time.Now().Add(5 * time.Second)
+			return m, uiTickCmd()
+		}
+		return m, nil
 	case tickMsg:
 		if !m.ctx.AutoRefresh {
 			return m, nil
 		}
-		return m, tea.Batch(m.fetchAllWorktrees(), tickCmd())
+		cmds := []tea.Cmd{m.fetchAllWorktrees(), tickCmd()}
+		if m.view == viewWorkingDiff {
+			cmds = append(cmds, diffview.FetchWorkingChanges(m.ctx.Git, m.diffWtPath, true))
+		}
+		return m, tea.Batch(cmds...)
 	case allWorktreesFetchedMsg:
 		m.ctx.Loading = false
 		m.ctx.LastRefresh = time.Now()
@@ -267,7 +466,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.ctx.ActiveRepo >= 0 && m.ctx.ActiveRepo < len(m.ctx.Repos) {
 			m.ctx.Repos[m.ctx.ActiveRepo].WorktreeCount = len(msg.Worktrees)
 		}
-		return m, m.fetchCommits()
+		return m, tea.Batch(m.fetchCommits(), m.fetchAIStats())
+	case aisummary.StatsFetchedMsg:
+		if m.ctx.ActiveRepo >= 0 && m.ctx.ActiveRepo < len(m.ctx.Repos) && m.ctx.Repos[m.ctx.ActiveRepo].Path == msg.RepoPath {
+			m.aiSummary, _ = m.aiSummary.Update(msg)
+		}
+		return m, nil
 	case commitsFetchedMsg:
 		if msg.err == nil {
 			m.sidePanel.SetCommits(msg.commits)
@@ -295,24 +499,91 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.ctx.Message = fmt.Sprintf("Error: %v", msg.err)
 		} else {
 			m.ctx.Message = fmt.Sprintf("Worktree %q removed", msg.name)
-			if paneID, ok := m.ctx.TmuxPanes[msg.path]; ok {
-				if paneID == m.ctx.TmuxVisiblePane {
-					m.ctx.TmuxVisiblePane = ""
-				}
-				tmux.KillPane(paneID)
-				delete(m.ctx.TmuxPanes, msg.path)
-				m.saveTmuxSessions()
-			}
+			m.forgetTmuxPane(msg.path)
+		}
+		m.ctx.MessageExpiry = time.Now().Add(5 * time.Second)
+		m.view = viewNormal
+		return m, tea.Batch(m.fetchActiveWorktrees(), uiTickCmd())
+	case worktreeMergedMsg:
+		switch {
+		case msg.err != nil:
+			m.ctx.Message = fmt.Sprintf("Error: %v", msg.err)
+		case msg.removed:
+			m.ctx.Message = fmt.Sprintf("Merged %s (%s) and removed %q", msg.branch, msg.strategy, msg.name)
+			m.forgetTmuxPane(msg.path)
+		default:
+			m.ctx.Message = fmt.Sprintf("Merged %s into default branch (%s)", msg.branch, msg.strategy)
+		}
+		m.ctx.MessageExpiry = time.Now().Add(5 * time.Second)
+		m.view = viewNormal
+		return m, tea.Batch(m.fetchActiveWorktrees(), uiTickCmd())
+	case worktreeMovedMsg:
+		if msg.moved {
+			m.rekeyTmuxPane(msg.oldPath, msg.newPath)
+		}
+		switch {
+		case msg.err != nil:
+			m.ctx.Message = fmt.Sprintf("Error: %v", msg.err)
+		case msg.moved:
+			m.ctx.Message = fmt.Sprintf("Worktree moved to %s", msg.newPath)
+		default:
+			m.ctx.Message = fmt.Sprintf("Updated %q", filepath.Base(msg.newPath))
+		}
+		m.ctx.MessageExpiry = time.Now().Add(5 * time.Second)
+		m.view = viewNormal
+		return m, tea.Batch(m.fetchActiveWorktrees(), uiTickCmd())
+	case worktreePushedMsg:
+		switch {
+		case msg.err != nil:
+			m.ctx.Message = fmt.Sprintf("Error: %v", msg.err)
+		case msg.result.SetUpstream:
+			m.ctx.Message = fmt.Sprintf("Pushed %s and set upstream to %s/%s", msg.branch, msg.result.Remote, msg.branch)
+		case msg.force:
+			m.ctx.Message = fmt.Sprintf("Force pushed %s (%s)", msg.branch, msg.result.Summary)
+		default:
+			m.ctx.Message = fmt.Sprintf("Pushed %s (%s)", msg.branch, msg.result.Summary)
+		}
+		m.ctx.MessageExpiry = time.Now().Add(5 * time.Second)
+		m.view = viewNormal
+		return m, tea.Batch(m.fetchActiveWorktrees(), uiTickCmd())
+	case worktreeLockedMsg:
+		switch {
+		case msg.err != nil:
+			m.ctx.Message = fmt.Sprintf("Error: %v", msg.err)
+		case msg.locked:
+			m.ctx.Message = fmt.Sprintf("Locked %q", msg.name)
+		default:
+			m.ctx.Message = fmt.Sprintf("Unlocked %q", msg.name)
 		}
 		m.ctx.MessageExpiry = time.Now().Add(5 * time.Second)
 		m.view = viewNormal
 		return m, tea.Batch(m.fetchActiveWorktrees(), uiTickCmd())
+	case worktreesPrunedMsg:
+		switch {
+		case msg.err != nil:
+			m.ctx.Message = fmt.Sprintf("Error: %v", msg.err)
+		case len(msg.names) == 0:
+			m.ctx.Message = "Nothing to prune"
+		default:
+			m.ctx.Message = fmt.Sprintf("Pruned %d stale worktree(s): %s", len(msg.names), strings.Join(msg.names, ", "))
+		}
+		m.ctx.MessageExpiry = time.Now().Add(5 * time.Second)
+		return m, tea.Batch(m.fetchActiveWorktrees(), uiTickCmd())
 	case rebaseFinishedMsg:
 		m.worktreeList = m.worktreeList.StopRebasing()
+		var conflictErr *git.ConflictError
+		if errors.As(msg.err, &conflictErr) {
+			m.ctx.Message = fmt.Sprintf("Update of %s (%s) stopped on conflicts", filepath.Base(msg.wtPath), msg.strategy.Describe())
+			m.ctx.MessageExpiry = time.Now().Add(5 * time.Second)
+			m.conflicts = conflicts.New(msg.wtPath, conflictErr.Operation, m.ctx.Width, m.ctx.Height)
+			m.conflicts, _ = m.conflicts.Update(conflicts.ConflictsFetchedMsg{Files: conflictErr.Files, Reset: true})
+			m.view = viewConflicts
+			return m, tea.Batch(m.fetchActiveWorktrees(), uiTickCmd())
+		}
 		if msg.err != nil {
-			m.ctx.Message = "Rebase failed — resolve manually in terminal (space)"
+			m.ctx.Message = fmt.Sprintf("Update (%s) failed: %v", msg.strategy.Describe(), msg.err)
 		} else {
-			m.ctx.Message = fmt.Sprintf("Rebased %s onto default branch", filepath.Base(msg.wtPath))
+			m.ctx.Message = fmt.Sprintf("Updated %s from default branch (%s)", filepath.Base(msg.wtPath), msg.strategy.Describe())
 		}
 		m.ctx.MessageExpiry = time.Now().Add(5 * time.Second)
 		return m, tea.Batch(m.fetchActiveWorktrees(), uiTickCmd())
@@ -323,6 +594,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.ctx.Width = msg.Width
 		m.ctx.Height = msg.Height
+		_, panelHeight := m.splitHeights()
+		m.sidePanel.SetSize(msg.Width, panelHeight)
 		if m.view == viewRepoPicker {
 			m.repoPicker.SetSize(msg.Width, msg.Height)
 		}
@@ -332,6 +605,36 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.view == viewRemoveWorktree {
 			m.worktreeRemove.SetSize(msg.Width, msg.Height)
 		}
+		if m.view == viewMergeWorktree {
+			m.worktreeMerge.SetSize(msg.Width, msg.Height)
+		}
+		if m.view == viewConflicts {
+			m.conflicts.SetSize(msg.Width, msg.Height)
+		}
+		if m.view == viewUpdatePicker {
+			m.updatePicker.SetSize(msg.Width, msg.Height)
+		}
+		if m.view == viewLockWorktree {
+			m.worktreeLock.SetSize(msg.Width, msg.Height)
+		}
+		if m.view == viewMoveWorktree {
+			m.worktreeMove.SetSize(msg.Width, msg.Height)
+		}
+		if m.view == viewStashes {
+			m.stashes.SetSize(msg.Width, msg.Height)
+		}
+		if m.view == viewPushWorktree {
+			m.worktreePush.SetSize(msg.Width, msg.Height)
+		}
+		if m.view == viewCommitDiff {
+			m.commitDiff.SetSize(msg.Width, msg.Height)
+		}
+		if m.view == viewWorkingDiff {
+			m.workingDiff.SetSize(msg.Width, msg.Height)
+		}
+		if m.view == viewGraph {
+			m.commitGraph.SetSize(msg.Width, msg.Height)
+		}
 		return m, nil
 	case tea.KeyMsg:
 		if msg.String() == "ctrl+c" {
@@ -398,12 +701,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch msg := msg.(type) {
 		case worktreecreate.WorktreeCreateRequestMsg:
 			repoPath := m.ctx.Repos[m.ctx.ActiveRepo].Path
-			name := msg.Name
+			opts := git.AddWorktreeOptions{Name: msg.Name, Branch: msg.Branch, Mode: msg.Mode, Base: msg.Base}
 			branch := msg.Branch
+			if msg.Mode == git.AddRemoteBranch {
+				branch = git.Branch{Name: msg.Branch, Remote: true}.LocalName()
+			}
+			template := m.ctx.Config.WorktreeTemplate(repoPath)
+			client := m.ctx.Git
 			m.worktreeCreate.Creating = true
 			return m, func() tea.Msg {
-				wtPath := filepath.Join(filepath.Dir(repoPath), name)
-				err := git.AddWorktree(repoPath, name, branch)
+				bareDir, _ := client.BareDir(repoPath)
+				wtPath, err := config.ExpandWorktreePath(template, repoPath, bareDir, opts.Name, branch)
+				if err != nil {
+					return worktreeCreatedMsg{err: err}
+				}
+				opts.Path = wtPath
+				err = client.AddWorktree(repoPath, opts)
 				return worktreeCreatedMsg{path: wtPath, err: err}
 			}
 		case worktreecreate.WorktreeCreateCancelledMsg:
@@ -424,9 +737,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			branch := msg.Branch
 			deleteBranch := msg.DeleteBranch
 			wtName := filepath.Base(wtPath)
+			client := m.ctx.Git
 			m.worktreeRemove.Removing = true
 			return m, func() tea.Msg {
-				err := git.RemoveWorktree(repoPath, wtPath, branch, deleteBranch)
+				err := client.RemoveWorktree(repoPath, wtPath, branch, deleteBranch)
 				return worktreeRemovedMsg{name: wtName, path: wtPath, err: err}
 			}
 		case worktreeremove.WorktreeRemoveCancelledMsg:
@@ -439,6 +753,264 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	if m.view == viewMergeWorktree {
+		switch msg := msg.(type) {
+		case worktreemerge.WorktreeMergeRequestMsg:
+			repoPath := m.ctx.Repos[m.ctx.ActiveRepo].Path
+			client := m.ctx.Git
+			m.worktreeMerge.Merging = true
+			return m, func() tea.Msg {
+				res := worktreeMergedMsg{
+					name:     filepath.Base(msg.WtPath),
+					path:     msg.WtPath,
+					branch:   msg.Branch,
+					strategy: msg.Strategy,
+				}
+				if res.err = client.MergeIntoDefault(repoPath, msg.Branch, msg.Strategy); res.err != nil {
+					return res
+				}
+				if msg.RemoveAfter {
+					if err := client.RemoveWorktree(repoPath, msg.WtPath, msg.Branch, true); err != nil {
+						res.err = fmt.Errorf("merged, but removing the worktree failed: %v", err)
+						return res
+					}
+					res.removed = true
+				}
+				return res
+			}
+		case worktreemerge.WorktreeMergeCancelledMsg:
+			m.view = viewNormal
+			return m, nil
+		default:
+			var cmd tea.Cmd
+			m.worktreeMerge, cmd = m.worktreeMerge.Update(msg)
+			return m, cmd
+		}
+	}
+
+	if m.view == viewPushWorktree {
+		switch msg := msg.(type) {
+		case worktreepush.WorktreePushRequestMsg:
+			client := m.ctx.Git
+			repoPath := m.ctx.Repos[m.ctx.ActiveRepo].Path
+			m.worktreePush.Pushing = true
+			return m, func() tea.Msg {
+				res, err := client.Push(repoPath, msg.WtPath, msg.Branch, git.PushOptions{ForceWithLease: msg.ForceWithLease})
+				return worktreePushedMsg{branch: msg.Branch, force: msg.ForceWithLease, result: res, err: err}
+			}
+		case worktreepush.WorktreePushCancelledMsg:
+			m.view = viewNormal
+			return m, nil
+		default:
+			var cmd tea.Cmd
+			m.worktreePush, cmd = m.worktreePush.Update(msg)
+			return m, cmd
+		}
+	}
+
+	if m.view == viewMoveWorktree {
+		switch msg := msg.(type) {
+		case worktreemove.WorktreeMoveRequestMsg:
+			repoPath := m.ctx.Repos[m.ctx.ActiveRepo].Path
+			client := m.ctx.Git
+			m.worktreeMove.Moving = true
+			return m, func() tea.Msg {
+				res := worktreeMovedMsg{oldPath: msg.WtPath, newPath: msg.Path}
+				res.moved, res.err = client.MoveWorktree(repoPath, msg.WtPath, git.MoveWorktreeOptions{
+					Path:      res.newPath,
+					Branch:    msg.Branch,
+					NewBranch: msg.NewBranch,
+				})
+				return res
+			}
+		case worktreemove.WorktreeMoveCancelledMsg:
+			m.view = viewNormal
+			return m, nil
+		default:
+			var cmd tea.Cmd
+			m.worktreeMove, cmd = m.worktreeMove.Update(msg)
+			return m, cmd
+		}
+	}
+
+	if m.view == viewLockWorktree {
+		switch msg := msg.(type) {
+		case worktreelock.WorktreeLockRequestMsg:
+			repoPath := m.ctx.Repos[m.ctx.ActiveRepo].Path
+			client := m.ctx.Git
+			return m, func() tea.Msg {
+				err := client.LockWorktree(repoPath, msg.WtPath, msg.Reason)
+				return worktreeLockedMsg{name: filepath.Base(msg.WtPath), locked: true, err: err}
+			}
+		case worktreelock.WorktreeLockCancelledMsg:
+			m.view = viewNormal
+			return m, nil
+		default:
+			var cmd tea.Cmd
+			m.worktreeLock, cmd = m.worktreeLock.Update(msg)
+			return m, cmd
+		}
+	}
+
+	if m.view == viewUpdatePicker {
+		switch msg := msg.(type) {
+		case updatepicker.UpdateRequestMsg:
+			repoPath := m.ctx.Repos[m.ctx.ActiveRepo].Path
+			client := m.ctx.Git
+			opts := git.UpdateOptions{Strategy: msg.Strategy, KeepConflicts: m.ctx.Config.KeepConflicts()}
+			var cmd tea.Cmd
+			m.worktreeList, cmd = m.worktreeList.StartRebasing(msg.WtPath)
+			m.view = viewNormal
+			return m, tea.Batch(cmd, func() tea.Msg {
+				err := client.UpdateWorktree(repoPath, msg.WtPath, opts)
+				return rebaseFinishedMsg{wtPath: msg.WtPath, strategy: msg.Strategy, err: err}
+			})
+		case updatepicker.UpdateCancelledMsg:
+			m.view = viewNormal
+			return m, nil
+		default:
+			var cmd tea.Cmd
+			m.updatePicker, cmd = m.updatePicker.Update(msg)
+			return m, cmd
+		}
+	}
+
+	if m.view == viewStashes {
+		wtPath := m.stashes.WtPath()
+		client := m.ctx.Git
+		switch msg := msg.(type) {
+		case stashes.StashActionMsg:
+			return m, func() tea.Msg {
+				var err error
+				switch msg.Action {
+				case stashes.ActionApply:
+					err = client.StashApply(wtPath, msg.Stash)
+				case stashes.ActionPop:
+					err = client.StashPop(wtPath, msg.Stash)
+				case stashes.ActionDrop:
+					err = client.StashDrop(wtPath, msg.Stash)
+				case stashes.ActionPush:
+					err = client.StashPush(wtPath, msg.Message)
+				}
+				return stashStepMsg{err: err}
+			}
+		case stashStepMsg:
+			// A conflicting pop still changes the worktree and keeps the
+			// stash, and a shifted list needs reloading, so reload in
+			// every case.
+			if msg.err != nil {
+				m.stashes.SetError(msg.err)
+			}
+			return m, stashes.FetchStashes(client, wtPath)
+		case stashes.StashesClosedMsg:
+			m.view = viewNormal
+			return m, m.fetchActiveWorktrees()
+		default:
+			var cmd tea.Cmd
+			m.stashes, cmd = m.stashes.Update(msg)
+			return m, cmd
+		}
+	}
+
+	if m.view == viewCommitDiff {
+		switch msg := msg.(type) {
+		case diffview.DiffClosedMsg:
+			m.view = viewNormal
+			return m, nil
+		default:
+			var cmd tea.Cmd
+			m.commitDiff, cmd = m.commitDiff.Update(msg)
+			return m, cmd
+		}
+	}
+
+	if m.view == viewWorkingDiff {
+		switch msg := msg.(type) {
+		case diffview.DiffClosedMsg:
+			m.view = viewNormal
+			return m, nil
+		default:
+			var cmd tea.Cmd
+			m.workingDiff, cmd = m.workingDiff.Update(msg)
+			return m, cmd
+		}
+	}
+
+	if m.view == viewGraph {
+		switch msg := msg.(type) {
+		case graph.GraphClosedMsg:
+			m.view = viewNormal
+			return m, nil
+		default:
+			var cmd tea.Cmd
+			m.commitGraph, cmd = m.commitGraph.Update(msg)
+			return m, cmd
+		}
+	}
+
+	if m.view == viewConflicts {
+		wtPath := m.conflicts.WtPath()
+		operation := m.conflicts.Operation()
+		client := m.ctx.Git
+		switch msg := msg.(type) {
+		case conflicts.OpenFileMsg:
+			return m, tea.ExecProcess(editorCommand(msg.Path), func(err error) tea.Msg {
+				return editorClosedMsg{err: err}
+			})
+		case editorClosedMsg:
+			if msg.err != nil {
+				m.conflicts.SetError(msg.err)
+			}
+			return m, conflicts.FetchConflicts(client, wtPath, false)
+		case conflicts.MarkResolvedMsg:
+			return m, func() tea.Msg {
+				if err := client.MarkResolved(wtPath, msg.File); err != nil {
+					return conflicts.ConflictsFetchedMsg{Err: err}
+				}
+				return conflicts.FetchConflicts(client, wtPath, false)()
+			}
+		case conflicts.ActionMsg:
+			return m, func() tea.Msg {
+				var err error
+				switch msg.Action {
+				case conflicts.ActionContinue:
+					err = client.Continue(wtPath, operation)
+				case conflicts.ActionSkip:
+					err = client.RebaseSkip(wtPath)
+				case conflicts.ActionAbort:
+					err = client.Abort(wtPath, operation)
+				}
+				return rebaseStepMsg{action: msg.Action, err: err}
+			}
+		case rebaseStepMsg:
+			var conflictErr *git.ConflictError
+			switch {
+			case errors.As(msg.err, &conflictErr):
+				m.conflicts, _ = m.conflicts.Update(conflicts.ConflictsFetchedMsg{Files: conflictErr.Files, Reset: true})
+				return m, nil
+			case msg.err != nil:
+				m.conflicts.SetError(msg.err)
+				return m, nil
+			case msg.action == conflicts.ActionAbort:
+				m.ctx.Message = fmt.Sprintf("%s of %s aborted", capitalize(operation), filepath.Base(wtPath))
+			default:
+				m.ctx.Message = fmt.Sprintf("Finished %s of %s", operation, filepath.Base(wtPath))
+			}
+			m.ctx.MessageExpiry = time.Now().Add(5 * time.Second)
+			m.view = viewNormal
+			return m, tea.Batch(m.fetchActiveWorktrees(), uiTickCmd())
+		case conflicts.ConflictsClosedMsg:
+			m.ctx.Message = capitalize(operation) + " still in progress — press c to resume"
+			m.ctx.MessageExpiry = time.Now().Add(5 * time.Second)
+			m.view = viewNormal
+			return m, tea.Batch(m.fetchActiveWorktrees(), uiTickCmd())
+		default:
+			var cmd tea.Cmd
+			m.conflicts, cmd = m.conflicts.Update(msg)
+			return m, cmd
+		}
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
@@ -461,14 +1033,109 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if len(m.ctx.Repos) > 0 {
 				m.worktreeCreate = worktreecreate.New(m.ctx.Width, m.ctx.Height)
 				m.view = viewCreateWorktree
-				return m, textinput.Blink
+				repoPath := m.ctx.Repos[m.ctx.ActiveRepo].Path
+				return m, tea.Batch(textinput.Blink, worktreecreate.FetchBranches(m.ctx.Git, repoPath))
 			}
 		case "x":
 			if wt, ok := m.worktreeList.SelectedWorktree(); ok {
+				if wt.Main {
+					return m, m.refuseOnMain("removed")
+				}
 				m.worktreeRemove = worktreeremove.New(filepath.Base(wt.Path), wt.Path, wt.Branch, m.ctx.Width, m.ctx.Height)
 				m.view = viewRemoveWorktree
 				return m, nil
 			}
+		case "m":
+			wt, ok := m.worktreeList.SelectedWorktree()
+			if !ok || wt.Branch == "" || wt.Branch == "(detached)" {
+				break
+			}
+			if wt.Main {
+				return m, m.refuseOnMain("merged into itself")
+			}
+			repoPath := m.ctx.Repos[m.ctx.ActiveRepo].Path
+			m.worktreeMerge = worktreemerge.New(wt.Path, wt.Branch, m.ctx.Width, m.ctx.Height)
+			m.view = viewMergeWorktree
+			return m, worktreemerge.FetchPreview(m.ctx.Git, repoPath, wt.Branch)
+		case "c":
+			wt, ok := m.worktreeList.SelectedWorktree()
+			if !ok {
+				break
+			}
+			// The view can only continue or abort a rebase or merge. Conflicts
+			// left by a cherry-pick, revert or stash pop are for the terminal.
+			if op := wt.Status.Operation; op != "rebase" && op != "merge" {
+				if wt.Status.Conflicted == 0 {
+					break
+				}
+				source := op
+				if source == "" {
+					source = "stash pop or checkout"
+				}
+				m.ctx.Message = fmt.Sprintf("Conflicts from a %s — resolve in terminal", source)
+				m.ctx.MessageExpiry = time.Now().Add(5 * time.Second)
+				return m, uiTickCmd()
+			}
+			m.conflicts = conflicts.New(wt.Path, wt.Status.Operation, m.ctx.Width, m.ctx.Height)
+			m.view = viewConflicts
+			return m, conflicts.FetchConflicts(m.ctx.Git, wt.Path, true)
+		case "p":
+			wt, ok := m.worktreeList.SelectedWorktree()
+			if !ok || wt.Branch == "" || wt.Branch == "(detached)" {
+				break
+			}
+			remote := m.ctx.Git.Remotes(m.ctx.Repos[m.ctx.ActiveRepo].Path).Push
+			m.worktreePush = worktreepush.New(wt, remote, m.ctx.Width, m.ctx.Height)
+			m.view = viewPushWorktree
+			return m, nil
+		case "s":
+			if wt, ok := m.worktreeList.SelectedWorktree(); ok {
+				m.stashes = stashes.New(m.ctx.Git, wt.Path, wt.Branch, m.ctx.Width, m.ctx.Height)
+				m.view = viewStashes
+				return m, stashes.FetchStashes(m.ctx.Git, wt.Path)
+			}
+		case "M":
+			if wt, ok := m.worktreeList.SelectedWorktree(); ok {
+				if wt.Main {
+					return m, m.refuseOnMain("moved")
+				}
+				m.worktreeMove = worktreemove.New(wt.Path, wt.Branch, m.ctx.Width, m.ctx.Height)
+				m.view = viewMoveWorktree
+				return m, m.worktreeMove.Init()
+			}
+		case "L":
+			wt, ok := m.worktreeList.SelectedWorktree()
+			if !ok {
+				break
+			}
+			if wt.Main {
+				return m, m.refuseOnMain("locked")
+			}
+			if !wt.Locked {
+				m.worktreeLock = worktreelock.New(wt.Path, m.ctx.Width, m.ctx.Height)
+				m.view = viewLockWorktree
+				return m, m.worktreeLock.Init()
+			}
+			repoPath := m.ctx.Repos[m.ctx.ActiveRepo].Path
+			client := m.ctx.Git
+			return m, func() tea.Msg {
+				err := client.UnlockWorktree(repoPath, wt.Path)
+				return worktreeLockedMsg{name: filepath.Base(wt.Path), locked: false, err: err}
+			}
+		case "P":
+			if len(m.ctx.Repos) == 0 {
+				break
+			}
+			repoPath := m.ctx.Repos[m.ctx.ActiveRepo].Path
+			client := m.ctx.Git
+			return m, func() tea.Msg {
+				names, err := client.PruneWorktrees(repoPath)
+				return worktreesPrunedMsg{names: names, err: err}
+			}
+		case "f":
+			if cmd := m.fetchAll(); cmd != nil {
+				return m, cmd
+			}
 		case "r":
 			if len(m.ctx.Repos) > 0 {
 				m.ctx.Loading = true
@@ -498,14 +1165,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if !ok || wt.Branch == "" || wt.Branch == "(detached)" {
 				break
 			}
+			if wt.Main {
+				return m, m.refuseOnMain("updated onto the default branch")
+			}
 			repoPath := m.ctx.Repos[m.ctx.ActiveRepo].Path
-			wtPath := wt.Path
-			var cmd tea.Cmd
-			m.worktreeList, cmd = m.worktreeList.StartRebasing(wtPath)
-			return m, tea.Batch(cmd, func() tea.Msg {
-				err := git.RebaseOnto(repoPath, wtPath)
-				return rebaseFinishedMsg{wtPath: wtPath, err: err}
-			})
+			strategy := git.ParseUpdateStrategy(m.ctx.Config.UpdateStrategy(repoPath))
+			m.updatePicker = updatepicker.New(wt.Path, strategy, m.ctx.Width, m.ctx.Height)
+			m.view = viewUpdatePicker
+			return m, nil
 		case "d":
 			if len(m.ctx.Repos) > 0 {
 				name := m.ctx.Repos[m.ctx.ActiveRepo].Name
@@ -561,6 +1228,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.ctx.ActiveRepo--
 				m.tabs.ScrollToActive()
 				m.hideTmuxPane()
+				m.aiSummary.Reset()
 				return m, m.fetchActiveWorktrees()
 			}
 		case "l", "right":
@@ -568,6 +1236,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.ctx.ActiveRepo++
 				m.tabs.ScrollToActive()
 				m.hideTmuxPane()
+				m.aiSummary.Reset()
 				return m, m.fetchActiveWorktrees()
 			}
 		case "j", "down", "k", "up":
@@ -582,12 +1251,50 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 			return m, tea.Batch(cmd, m.fetchCommits())
+		case "g":
+			if len(m.ctx.Repos) == 0 {
+				break
+			}
+			repo := m.ctx.Repos[m.ctx.ActiveRepo]
+			m.commitGraph = graph.New(repo.Name, m.ctx.Width, m.ctx.Height)
+			m.view = viewGraph
+			return m, graph.FetchGraph(m.ctx.Git, repo.Path)
+		case "D":
+			wt, ok := m.worktreeList.SelectedWorktree()
+			if !ok || wt.Prunable {
+				break
+			}
+			m.workingDiff = diffview.New("Uncommitted changes in "+filepath.Base(wt.Path), wt.Path, m.ctx.Width, m.ctx.Height)
+			m.diffWtPath = wt.Path
+			m.view = viewWorkingDiff
+			return m, diffview.FetchWorkingChanges(m.ctx.Git, wt.Path, false)
+		case "enter":
+			c, ok := m.sidePanel.SelectedCommit()
+			if !ok {
+				break
+			}
+			repoPath := m.ctx.Repos[m.ctx.ActiveRepo].Path
+			m.commitDiff = diffview.New(c.Hash[:7]+" "+c.Subject, c.Hash, m.ctx.Width, m.ctx.Height)
+			if file, ok := m.sidePanel.SelectedFile(); ok {
+				m.commitDiff.JumpTo(file)
+			}
+			m.view = viewCommitDiff
+			return m, diffview.FetchCommitDiff(m.ctx.Git, repoPath, c.Hash)
 		case "[":
 			m.sidePanel.PrevCommit()
 			return m, nil
 		case "]":
 			m.sidePanel.NextCommit()
 			return m, nil
+		case "tab":
+			m.sidePanel.ToggleFocus()
+			return m, nil
+		case "J":
+			m.sidePanel.Scroll(1)
+			return m, nil
+		case "K":
+			m.sidePanel.Scroll(-1)
+			return m, nil
 		}
 	}
 
@@ -607,6 +1314,46 @@ func (m Model) View() string {
 		return m.worktreeRemove.View()
 	}
 
+	if m.view == viewMergeWorktree {
+		return m.worktreeMerge.View()
+	}
+
+	if m.view == viewConflicts {
+		return m.conflicts.View()
+	}
+
+	if m.view == viewUpdatePicker {
+		return m.updatePicker.View()
+	}
+
+	if m.view == viewLockWorktree {
+		return m.worktreeLock.View()
+	}
+
+	if m.view == viewMoveWorktree {
+		return m.worktreeMove.View()
+	}
+
+	if m.view == viewStashes {
+		return m.stashes.View()
+	}
+
+	if m.view == viewPushWorktree {
+		return m.worktreePush.View()
+	}
+
+	if m.view == viewCommitDiff {
+		return m.commitDiff.View()
+	}
+
+	if m.view == viewWorkingDiff {
+		return m.workingDiff.View()
+	}
+
+	if m.view == viewGraph {
+		return m.commitGraph.View()
+	}
+
 	top := m.tabs.View()
 	foot := m.footer.View()
 
@@ -628,23 +1375,58 @@ func (m Model) View() string {
 					Render("Press 'a' to add your first repository"))
 		content = lipgloss.Place(m.ctx.Width, mid, lipgloss.Center, lipgloss.Center, welcome)
 	} else if m.worktreeList.HasWorktrees() {
-		panelHeight := mid / 3
-		if panelHeight < 5 {
-			panelHeight = 5
-		}
-		listHeight := mid - panelHeight
-		if listHeight < 0 {
-			listHeight = 0
-		}
+		listHeight, panelHeight := m.splitHeights()
 		if wt, ok := m.worktreeList.SelectedWorktree(); ok {
 			m.sidePanel.SetWorktree(&wt)
 		}
+		m.aiSummary.SetWorktrees(m.worktreeList.Worktrees())
+		summary := m.aiSummary.View(m.ctx.Width)
 		list := m.worktreeList.View(m.ctx.Width, listHeight)
 		panel := m.sidePanel.View(m.ctx.Width, panelHeight)
-		content = list + "\n" + panel
+		content = summary + "\n" + list + "\n" + panel
 	} else {
 		content = m.worktreeList.View(m.ctx.Width, mid)
 	}
 
 	return top + "\n" + content + "\n" + foot
 }
+
+// splitHeights divides the space between the AI summary and the footer
+// between the worktree list and the side panel below it.
+func (m Model) splitHeights() (list, panel int) {
+	mid := m.ctx.Height - lipgloss.Height(m.tabs.View()) - aisummary.Height - lipgloss.Height(m.footer.View())
+	if mid < 0 {
+		mid = 0
+	}
+	panel = mid / 3
+	if panel < 5 {
+		panel = 5
+	}
+	list = mid - panel
+	if list < 0 {
+		list = 0
+	}
+	return list, panel
+}
+
+// capitalize upper-cases the first letter of a git operation name.
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// editorCommand opens path in the user's editor, honouring arguments in
+// $VISUAL or $EDITOR (e.g. "code --wait").
+func editorCommand(path string) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	fields := strings.Fields(editor)
+	return exec.Command(fields[0], append(fields[1:], path)...)
+}